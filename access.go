@@ -0,0 +1,157 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// At returns the argument at index i of Args.
+// It returns an error if i is out of range.
+func (v *Value) At(i int) (string, error) {
+	args := v.Args()
+	if i < 0 || i >= len(args) {
+		return "", fmt.Errorf("index %d out of range: %d arguments", i, len(args))
+	}
+	return args[i], nil
+}
+
+// IntAt returns the argument at index i of Args, converted to an int.
+func (v *Value) IntAt(i int) (int, error) {
+	s, err := v.At(i)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(s)
+}
+
+// FloatAt returns the argument at index i of Args, converted to a float64.
+func (v *Value) FloatAt(i int) (float64, error) {
+	s, err := v.At(i)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// DurationAt returns the argument at index i of Args, converted to a time.Duration.
+func (v *Value) DurationAt(i int) (time.Duration, error) {
+	s, err := v.At(i)
+	if err != nil {
+		return 0, err
+	}
+	return time.ParseDuration(s)
+}
+
+// BoolAt returns the argument at index i of Args, converted to a bool.
+func (v *Value) BoolAt(i int) (bool, error) {
+	s, err := v.At(i)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(s)
+}
+
+// ConversionErrors determines how the typed accessors, such as Ints, treat arguments that cannot be converted.
+type ConversionErrors int
+
+const (
+	AllErrors  ConversionErrors = iota // convert every argument and report all errors together
+	FirstError                         // stop at, and report, the first error
+)
+
+// DefaultConversionErrors applies to values that have not been configured with WithConversionErrors.
+var DefaultConversionErrors = AllErrors
+
+// WithConversionErrors sets the treatment of conversion errors by the typed accessors of v,
+// overriding DefaultConversionErrors.
+// WithConversionErrors returns v to permit chaining.
+func (v *Value) WithConversionErrors(c ConversionErrors) *Value {
+	v.convErrs = &c
+	return v
+}
+
+// convertArgs calls convert with the index and value of each argument,
+// and returns the errors, annotated with the index, as determined by the ConversionErrors of v.
+// It reports whether the conversion ran to completion.
+func (v *Value) convertArgs(args []string, convert func(i int, s string) error) (bool, error) {
+	c := DefaultConversionErrors
+	if v.convErrs != nil {
+		c = *v.convErrs
+	}
+	var errs []error
+	for i, arg := range args {
+		if err := convert(i, arg); err != nil {
+			err = fmt.Errorf("argument %d: %w", i, err)
+			if c == FirstError {
+				return false, err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return true, errors.Join(errs...)
+}
+
+// Ints returns the arguments converted to ints.
+// An argument that cannot be converted produces a zero in its position and an error.
+// The errors for all such arguments are returned together or, if v is configured with FirstError,
+// the first error is returned, with no values.
+func (v *Value) Ints() ([]int, error) {
+	args := v.Args()
+	out := make([]int, len(args))
+	ok, err := v.convertArgs(args, func(i int, s string) (err error) {
+		out[i], err = strconv.Atoi(s)
+		return
+	})
+	if !ok {
+		return nil, err
+	}
+	return out, err
+}
+
+// Floats returns the arguments converted to float64s. See Ints for the treatment of errors.
+func (v *Value) Floats() ([]float64, error) {
+	args := v.Args()
+	out := make([]float64, len(args))
+	ok, err := v.convertArgs(args, func(i int, s string) (err error) {
+		out[i], err = strconv.ParseFloat(s, 64)
+		return
+	})
+	if !ok {
+		return nil, err
+	}
+	return out, err
+}
+
+// Durations returns the arguments converted to time.Durations. See Ints for the treatment of errors.
+func (v *Value) Durations() ([]time.Duration, error) {
+	args := v.Args()
+	out := make([]time.Duration, len(args))
+	ok, err := v.convertArgs(args, func(i int, s string) (err error) {
+		out[i], err = time.ParseDuration(s)
+		return
+	})
+	if !ok {
+		return nil, err
+	}
+	return out, err
+}
+
+// Bools returns the arguments converted to bools. See Ints for the treatment of errors.
+func (v *Value) Bools() ([]bool, error) {
+	args := v.Args()
+	out := make([]bool, len(args))
+	ok, err := v.convertArgs(args, func(i int, s string) (err error) {
+		out[i], err = strconv.ParseBool(s)
+		return
+	})
+	if !ok {
+		return nil, err
+	}
+	return out, err
+}
@@ -0,0 +1,88 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"flag"
+	"fmt"
+)
+
+// Encoding denotes the textual encoding of a Binary flag argument.
+type Encoding int
+
+const (
+	Base64    Encoding = iota // standard base64, as in RFC 4648
+	Base64URL                 // URL and filename safe base64, as in RFC 4648
+	Hex                       // hexadecimal
+)
+
+// Decode converts an encoded argument into bytes.
+func (e Encoding) Decode(s string) ([]byte, error) {
+	switch e {
+	case Base64:
+		return base64.StdEncoding.DecodeString(s)
+	case Base64URL:
+		return base64.URLEncoding.DecodeString(s)
+	case Hex:
+		return hex.DecodeString(s)
+	}
+	return nil, fmt.Errorf("unknown encoding %d", int(e))
+}
+
+// BinaryValue collects repeated flag arguments that are decoded and passed to an encoding.BinaryUnmarshaler.
+type BinaryValue struct {
+	*Value
+	enc  Encoding
+	newT func() encoding.BinaryUnmarshaler
+}
+
+func (b *BinaryValue) decode(s string) (encoding.BinaryUnmarshaler, error) {
+	data, err := b.enc.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	u := b.newT()
+	if err := u.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func newBinary(fn Flagger, name string, value string, usage string, enc Encoding, newT func() encoding.BinaryUnmarshaler, aliases ...string) *BinaryValue {
+	b := &BinaryValue{Value: newString(fn, name, value, usage, aliases...), enc: enc, newT: newT}
+	b.check = func(s string) error {
+		_, err := b.decode(s)
+		return err
+	}
+	b.checkDefaults()
+	return b
+}
+
+// Binary returns a multiflag instance, associated with flag, whose arguments are decoded with enc
+// and passed to an encoding.BinaryUnmarshaler. newT is called to produce a fresh value for each argument.
+// Arguments that fail to decode are rejected when the flag is parsed.
+func Binary(name string, value string, usage string, enc Encoding, newT func() encoding.BinaryUnmarshaler, aliases ...string) *BinaryValue {
+	return newBinary(flag.Var, name, value, usage, enc, newT, aliases...)
+}
+
+// BinarySet creates a Binary multiflag instance, associates it with the provided FlagSet and returns it.
+func BinarySet(flg *flag.FlagSet, name string, value string, usage string, enc Encoding, newT func() encoding.BinaryUnmarshaler, aliases ...string) *BinaryValue {
+	return newBinary(flg.Var, name, value, usage, enc, newT, aliases...)
+}
+
+// Values returns the decoded arguments, in the order they were given.
+func (b *BinaryValue) Values() []encoding.BinaryUnmarshaler {
+	args := b.Args()
+	vals := make([]encoding.BinaryUnmarshaler, 0, len(args))
+	for _, arg := range args {
+		if u, err := b.decode(arg); err == nil {
+			vals = append(vals, u)
+		}
+	}
+	return vals
+}
@@ -0,0 +1,141 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ValueHint describes the values of a flag, so that shell completion can offer suitable candidates.
+type ValueHint int
+
+const (
+	AnyValue  ValueHint = iota // arbitrary values; no completion is offered
+	FileValue                  // file names
+	DirValue                   // directory names
+)
+
+// CompleteFunc returns the completion candidates for a flag value that begins with prefix.
+type CompleteFunc func(prefix string) []string
+
+// CompleteCommand is the first argument with which generated completion scripts invoke the program
+// to complete the value of a flag that has a CompleteFunc. See HandleCompletion.
+const CompleteCommand = "__complete"
+
+// Complete causes the values of v to be completed by calling fn when the program is invoked
+// by a generated completion script. See HandleCompletion.
+// Complete returns v to permit chaining.
+func (v *Value) Complete(fn CompleteFunc) *Value {
+	v.complete = fn
+	return v
+}
+
+// Candidates returns the completion candidates for a value of v that begins with prefix.
+// They are produced by the CompleteFunc of v or, if it has none, are its choices; see Enum.
+func (v *Value) Candidates(prefix string) []string {
+	candidates := v.choices
+	if v.complete != nil {
+		candidates = v.complete(prefix)
+	}
+	var matches []string
+	for _, s := range candidates {
+		if strings.HasPrefix(s, prefix) {
+			matches = append(matches, s)
+		}
+	}
+	return matches
+}
+
+// Hint returns the kind of values of v, as set by WithHint.
+func (v *Value) Hint() ValueHint {
+	return v.hint
+}
+
+// HandleCompletion handles the invocation of the program by a generated completion script,
+// which takes the form
+//
+//	program __complete flag prefix
+//
+// It writes, to w, the candidates for the value of the named flag, or alias, that begin with prefix,
+// one per line, and returns true. The candidates are those given by Candidates.
+// If args does not begin with CompleteCommand, it does nothing and returns false. A program calls it before parsing its arguments, as in
+//
+//	if multiflag.HandleCompletion(flag.CommandLine, os.Stdout, os.Args[1:]) {
+//		os.Exit(0)
+//	}
+func HandleCompletion(fs *flag.FlagSet, w io.Writer, args []string) bool {
+	if len(args) == 0 || args[0] != CompleteCommand {
+		return false
+	}
+	if len(args) < 2 {
+		return true
+	}
+	prefix := ""
+	if len(args) > 2 {
+		prefix = args[2]
+	}
+	if v, _, ok := Lookup(fs, args[1]); ok {
+		for _, s := range v.Candidates(prefix) {
+			fmt.Fprintln(w, s)
+		}
+	}
+	return true
+}
+
+// fishQuote quotes s as a fish single quoted string.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+// fishNames returns the fish complete options that match a flag's names:
+// -s for one letter names and -o for others, which fish calls old style options.
+func fishNames(info FlagInfo) string {
+	var opts []string
+	for _, name := range append([]string{info.Name}, info.Aliases...) {
+		if len(name) == 1 {
+			opts = append(opts, "-s "+fishQuote(name))
+		} else {
+			opts = append(opts, "-o "+fishQuote(name))
+		}
+	}
+	return strings.Join(opts, " ")
+}
+
+// WriteFishCompletion writes, to w, fish complete commands for command that describe the flags in fs,
+// their aliases and their negating flags. The description of each flag is its usage text, and the values
+// of a flag that takes an argument are completed by calling the program, for a flag with a CompleteFunc,
+// from its choices, for an Enum, or according to its ValueHint.
+func WriteFishCompletion(fs *flag.FlagSet, w io.Writer, command string) error {
+	var b strings.Builder
+	for _, info := range Flags(fs) {
+		desc := strings.SplitN(info.Usage, "\n", 2)[0]
+		fmt.Fprintf(&b, "complete -c %s %s -d %s", fishQuote(command), fishNames(info), fishQuote(desc))
+		if info.Placeholder != "" {
+			switch {
+			case info.Dynamic:
+				call := fmt.Sprintf("%s %s %s (commandline -ct)", command, CompleteCommand, info.Name)
+				fmt.Fprintf(&b, " -x -a %s", fishQuote("("+call+")"))
+			case len(info.Choices) > 0:
+				fmt.Fprintf(&b, " -x -a %s", fishQuote(strings.Join(info.Choices, " ")))
+			case info.Hint == FileValue:
+				b.WriteString(" -r -F")
+			case info.Hint == DirValue:
+				b.WriteString(" -x -a '(__fish_complete_directories)'")
+			default:
+				b.WriteString(" -x")
+			}
+		}
+		b.WriteString("\n")
+		for _, name := range info.Negations {
+			fmt.Fprintf(&b, "complete -c %s -o %s -d %s\n", fishQuote(command), fishQuote(name), fishQuote(fmt.Sprintf(messages.Negates, "-"+info.Name)))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,42 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestNegationsDocumented(t *testing.T) {
+	fs := flag.NewFlagSet("doc", flag.ContinueOnError)
+	BoolSet(fs, "verbose", "", "verbosity", "v").Negatable(false)
+
+	var fish, md, man bytes.Buffer
+	if err := WriteFishCompletion(fs, &fish, "app"); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteMarkdown(fs, &md); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteMan(fs, &man); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name, out string
+		want      []string
+	}{
+		{"fish", fish.String(), []string{"-o 'no-verbose'", "-o 'no-v'"}},
+		{"markdown", md.String(), []string{"`-no-verbose`", "`-no-v`"}},
+		{"man", man.String(), []string{`\fB\-no\-verbose\fR`, `\fB\-no\-v\fR`}},
+	} {
+		for _, want := range tc.want {
+			if !strings.Contains(tc.out, want) {
+				t.Errorf("%s: %s missing from\n%s", tc.name, want, tc.out)
+			}
+		}
+	}
+}
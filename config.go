@@ -0,0 +1,129 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// configArgs converts a configuration value into flag arguments.
+// An array produces an argument per element and a map produces a key=value argument per entry,
+// in key order. A nil value produces no arguments.
+func configArgs(x interface{}) ([]string, error) {
+	switch x := x.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{x}, nil
+	case []interface{}:
+		var args []string
+		for _, elem := range x {
+			switch elem.(type) {
+			case []interface{}, map[string]interface{}:
+				return nil, fmt.Errorf("nested value %v is not supported", elem)
+			}
+			more, err := configArgs(elem)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, more...)
+		}
+		return args, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for key := range x {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var args []string
+		for _, key := range keys {
+			switch x[key].(type) {
+			case []interface{}, map[string]interface{}:
+				return nil, fmt.Errorf("nested value for key %q is not supported", key)
+			}
+			args = append(args, fmt.Sprintf("%s=%v", key, x[key]))
+		}
+		return args, nil
+	}
+	return []string{fmt.Sprint(x)}, nil
+}
+
+// ApplyConfig sets the flags in fs from values, which maps flag names, or aliases, to values.
+// A value may be a scalar, which is applied as a single argument, or an array of scalars,
+// which is applied as repeated arguments. A map is applied as key=value arguments.
+// Arguments to multiflag values are validated and split as on the command line, but rank below the
+// command line and the environment as described for Precedence, and do not cause IsSet to return true.
+// Other flags are set with fs.Set, unless they have already been set on the command line.
+// Names are processed in lexicographical order; the first error is returned.
+func ApplyConfig(fs *flag.FlagSet, values map[string]interface{}) error {
+	return ApplyConfigFrom(fs, "", values)
+}
+
+// ApplyConfigFrom is ApplyConfig for values read from origin, typically a file name,
+// which is recorded in the resulting Occurrences.
+func ApplyConfigFrom(fs *flag.FlagSet, origin string, values map[string]interface{}) error {
+	given := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := fs.Lookup(name)
+		if f == nil {
+			return fmt.Errorf(messages.UndefinedFlag, name)
+		}
+
+		args, err := configArgs(values[name])
+		if err != nil {
+			return fmt.Errorf("flag -%s: %v", name, err)
+		}
+
+		v, ok := valueOf(f)
+		if !ok && given[name] {
+			continue
+		}
+		for _, arg := range args {
+			if ok {
+				if err := v.setFrom(arg, SourceConfig, origin, DefaultDelimiter); err != nil {
+					return &FlagError{Name: v.name, Used: name, Value: arg, HasValue: true, Err: err}
+				}
+			} else if err := fs.Set(name, arg); err != nil {
+				return fmt.Errorf(messages.InvalidValue, arg, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadJSON reads the JSON file at path, which must contain an object mapping flag names to values,
+// and applies it to fs with ApplyConfig.
+// Use flag.CommandLine for values created with the package level constructors.
+func LoadJSON(fs *flag.FlagSet, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var values map[string]interface{}
+	dec := json.NewDecoder(f)
+	dec.UseNumber()
+	if err := dec.Decode(&values); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	if err := ApplyConfigFrom(fs, path, values); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Loader applies the configuration file at path to fs.
+// LoadJSON and LoadINI are Loaders.
+type Loader func(fs *flag.FlagSet, path string) error
+
+func newConfigFile(fn Flagger, fs *flag.FlagSet, name string, value string, usage string, load Loader, aliases ...string) *Value {
+	v := newString(fn, name, value, usage, aliases...)
+	// loading holds the files being loaded, so that a file that names itself, or another file that does,
+	// is reported rather than loaded without end.
+	loading := make(map[string]bool)
+	loadOnce := func(path string) error {
+		key := path
+		if abs, err := filepath.Abs(path); err == nil {
+			key = abs
+		}
+		if loading[key] {
+			return fmt.Errorf("configuration file %s loads itself", path)
+		}
+		loading[key] = true
+		defer delete(loading, key)
+		return load(fs, path)
+	}
+	v.check = loadOnce
+	v.checkLoads = true
+	v.after = func() error {
+		if len(v.effective()) > 0 || value == "" {
+			return nil
+		}
+		if _, err := os.Stat(value); os.IsNotExist(err) {
+			return nil
+		}
+		if err := loadOnce(value); err != nil {
+			return fmt.Errorf("flag -%s: %v", name, err)
+		}
+		return nil
+	}
+	return v
+}
+
+// ConfigFile returns a string multiflag instance, associated with flag, that names configuration files.
+// Each file is loaded, with load, when the flag is parsed. Since configuration values rank below the
+// command line, as described for ApplyConfig, the flag may appear anywhere on the command line.
+// If the flag is not given, Parse loads the file named by value, if it exists.
+func ConfigFile(name string, value string, usage string, load Loader, aliases ...string) *Value {
+	return newConfigFile(flag.Var, flag.CommandLine, name, value, usage, load, aliases...)
+}
+
+// ConfigFileSet creates a ConfigFile multiflag instance, associates it with the provided FlagSet and returns it.
+func ConfigFileSet(flg *flag.FlagSet, name string, value string, usage string, load Loader, aliases ...string) *Value {
+	return newConfigFile(flg.Var, flg, name, value, usage, load, aliases...)
+}
@@ -0,0 +1,67 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newConfigFlagSet() (*flag.FlagSet, *Value) {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	s := StringSet(fs, "s", "", "strings")
+	ConfigFileSet(fs, "config", "", "configuration files", LoadJSON)
+	return fs, s
+}
+
+func TestConfigFileSaveAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json")
+	if err := os.WriteFile(path, []byte(`{"s": ["a"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fs, _ := newConfigFlagSet()
+	if err := Parse(fs, []string{"-config", path}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := SaveConfig(fs, &buf, JSON); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+	if strings.Contains(buf.String(), "config") {
+		t.Errorf("saved configuration names the configuration file: %s", buf.String())
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fs, s := newConfigFlagSet()
+	if err := Parse(fs, []string{"-config", path}); err != nil {
+		t.Fatalf("Parse of saved configuration: %v", err)
+	}
+	if got := s.Args(); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("got %q, want [a]", got)
+	}
+}
+
+func TestConfigFileLoadsItself(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(`{"config": [%q]}`, path)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fs, _ := newConfigFlagSet()
+	err := Parse(fs, []string{"-config", path})
+	if err == nil || !strings.Contains(err.Error(), "loads itself") {
+		t.Errorf("got %v, want an error reporting the cycle", err)
+	}
+}
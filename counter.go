@@ -0,0 +1,200 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseCount interprets an argument of a Bool: a signed integer, such as -1, that adjusts the count,
+// an unsigned integer, such as 2, that sets it, or a boolean, of which true increments the count
+// and false resets it to zero.
+// It returns the amount and whether it is relative to the count.
+func parseCount(s string) (n int, relative bool, err error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-"), nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return 0, false, err
+	}
+	if b {
+		return 1, true, nil
+	}
+	return 0, false, nil
+}
+
+// negation is the value of a flag, such as -no-verbose, that cancels uses of a Bool.
+type negation struct {
+	v    *Value
+	name string
+}
+
+func (n *negation) String() string { return "" }
+
+// Set records the use of n under its name, as for an alias. See negate.
+func (n *negation) Set(s string) error {
+	return n.v.setAs(s, n.name)
+}
+
+func (n *negation) IsBoolFlag() bool { return true }
+
+// Type returns the name of the value type, for the github.com/spf13/pflag package.
+func (n *negation) Type() string { return "bool" }
+
+// negate returns the argument that records, for the Bool v, a use of a negating flag given s,
+// or false if s is false, so that the use has no effect.
+func (v *Value) negate(s string) (string, bool, error) {
+	if b, err := strconv.ParseBool(s); err != nil || !b {
+		return "", false, err
+	}
+	if v.negateZero {
+		return "false", true, nil
+	}
+	return "-1", true, nil
+}
+
+// Negatable registers, for the Bool v, a negating flag named by prefixing "no-" to its name and to each alias,
+// as in -no-verbose and -no-v. Each use of a negating flag decrements the count of v or,
+// if zero is true, resets it to zero, so that a script can cancel the verbosity of a command it wraps.
+// Negating flags are not shown separately in usage output.
+// Negatable returns v to permit chaining.
+func (v *Value) Negatable(zero bool) *Value {
+	v.negateZero = zero
+	for _, name := range append([]string{v.name}, v.aliases...) {
+		v.negations = append(v.negations, "no-"+name)
+		v.flagger(&negation{v: v, name: "no-" + name}, "no-"+name, fmt.Sprintf(messages.Negates, v.name))
+	}
+	return v
+}
+
+// CounterValue is a Bool whose uses are counted, as by -v -v, and that may be paired with a quiet flag,
+// such as -q, whose uses are subtracted from the count. See Level.
+type CounterValue struct {
+	*Value
+	quiet *Value
+}
+
+func newCounter(fn Flagger, name string, value string, usage string, aliases ...string) *CounterValue {
+	return &CounterValue{Value: newBool(fn, name, value, usage, aliases...)}
+}
+
+// Counter returns a counting multiflag instance associated with flag.
+// It is a Bool, paired, by Quiet, with a flag that reduces its Level.
+func Counter(name string, value string, usage string, aliases ...string) *CounterValue {
+	return newCounter(flag.Var, name, value, usage, aliases...)
+}
+
+// CounterSet creates a Counter multiflag instance, associates it with the provided FlagSet and returns it.
+func CounterSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *CounterValue {
+	return newCounter(flg.Var, name, value, usage, aliases...)
+}
+
+// Quiet registers a Bool, with name, usage and aliases, in the same place as c, whose count is subtracted
+// from that of c by Level, so that -v -v -q is the same as -v, in the manner of rsync and ssh.
+// Quiet returns c to permit chaining.
+func (c *CounterValue) Quiet(name string, usage string, aliases ...string) *CounterValue {
+	c.quiet = newBool(c.flagger, name, "", usage, aliases...)
+	return c
+}
+
+// QuietValue returns the Bool registered by Quiet, or nil.
+func (c *CounterValue) QuietValue() *Value {
+	return c.quiet
+}
+
+// Level returns the count of c, as given by Count, less that of its quiet flag.
+// It is negative if the quiet flag was used more often.
+func (c *CounterValue) Level() int {
+	n := c.Count()
+	if c.quiet != nil {
+		n -= c.quiet.NArg()
+	}
+	return n
+}
+
+// count returns the count of a Bool given its arguments, and whether any of them
+// would have raised the count beyond the limit set by WithMax.
+func (v *Value) count(args []string) (n int, capped bool) {
+	for _, arg := range args {
+		if d, relative, err := parseCount(arg); err == nil && relative {
+			n += d
+		} else {
+			n = d
+		}
+		if n < 0 && !v.signed {
+			n = 0
+		}
+		if v.countMax > 0 && n > v.countMax {
+			n, capped = v.countMax, true
+		}
+	}
+	return n, capped
+}
+
+// WithMax limits the count of the Bool v, as returned by NArg, to n, so that, for example,
+// verbosity stops at 4 however often -v is given. Further uses are ignored and, if warn is true,
+// the first of them from each source causes a warning to WarningOutput.
+// WithMax returns v to permit chaining.
+func (v *Value) WithMax(n int, warn bool) *Value {
+	v.countMax, v.warnMax = n, warn
+	return v
+}
+
+// Signed permits the count of the Bool v to fall below zero, as when -no-verbose, or -verbose=-1,
+// is given more often than -verbose, so that it can be mapped to log levels around a neutral default.
+// The signed count is returned by Count; NArg does not go below zero.
+// Signed returns v to permit chaining.
+func (v *Value) Signed() *Value {
+	v.signed = true
+	return v
+}
+
+// Count returns the count of the Bool v, which, unlike NArg, is negative if v is Signed
+// and has been decremented below zero. For other values, it is the same as NArg.
+func (v *Value) Count() int {
+	if !v.isBool {
+		return v.NArg()
+	}
+	n, _ := v.count(v.collected())
+	return n
+}
+
+// Toggle causes each use of the Bool v to flip its value, as returned by BoolValue, rather than add to it,
+// so that a flag given an odd number of times, as by layered shell wrappers, inverts the default.
+// A false value, such as -debug=false, still clears it and a count, such as -debug=2, sets it by its parity.
+// NArg still counts the uses.
+// Toggle returns v to permit chaining.
+func (v *Value) Toggle() *Value {
+	v.toggle = true
+	return v
+}
+
+// toggled returns the value of a Toggle given its arguments.
+func (v *Value) toggled(args []string) bool {
+	b, _ := strconv.ParseBool(v.val)
+	for _, arg := range args {
+		n, relative, _ := parseCount(arg)
+		if relative {
+			b = b != (n%2 != 0)
+		} else {
+			b = n%2 != 0
+		}
+	}
+	return b
+}
+
+// V reports whether the count of the Bool v, as given by Count, is at least n, in the manner of glog and klog,
+// so that expensive output can be guarded, as in
+//
+//	if verbosity.V(3) {
+//		log.Printf("state: %v", dump())
+//	}
+func (v *Value) V(n int) bool {
+	return v.Count() >= n
+}
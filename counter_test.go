@@ -0,0 +1,82 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+)
+
+func TestParseCount(t *testing.T) {
+	for _, tc := range []struct {
+		arg      string
+		n        int
+		relative bool
+		err      bool
+	}{
+		{arg: "true", n: 1, relative: true},
+		{arg: "false", n: 0},
+		{arg: "3", n: 3},
+		{arg: "+2", n: 2, relative: true},
+		{arg: "-1", n: -1, relative: true},
+		{arg: "maybe", err: true},
+	} {
+		n, relative, err := parseCount(tc.arg)
+		if (err != nil) != tc.err || err == nil && (n != tc.n || relative != tc.relative) {
+			t.Errorf("parseCount(%q): got %d, %v, %v", tc.arg, n, relative, err)
+		}
+	}
+}
+
+func TestCountFromSources(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		configs []interface{}
+		env     map[string]string
+		args    []string
+		want    int
+	}{
+		{name: "command line", args: []string{"-v", "-v", "-verbose=3", "-v"}, want: 4},
+		{name: "config count", configs: []interface{}{float64(2)}, want: 2},
+		{name: "config files do not add up", configs: []interface{}{float64(2), float64(2)}, want: 2},
+		{name: "config boolean", configs: []interface{}{true}, want: 1},
+		{name: "environment count", env: map[string]string{"VERBOSE": "3"}, want: 3},
+		{name: "command line outranks config", configs: []interface{}{float64(3)}, args: []string{"-v"}, want: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("count", flag.ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+			v := BoolSet(fs, "verbose", "", "verbosity", "v")
+			for _, config := range tc.configs {
+				if err := ApplyConfig(fs, map[string]interface{}{"verbose": config}); err != nil {
+					t.Fatalf("ApplyConfig: %v", err)
+				}
+			}
+			if err := bindEnv(fs, "", env(tc.env)); err != nil {
+				t.Fatalf("bindEnv: %v", err)
+			}
+			if err := Parse(fs, tc.args); err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := v.NArg(); got != tc.want {
+				t.Errorf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCountIsOneOccurrence(t *testing.T) {
+	for _, src := range []Source{SourceConfig, SourceEnv} {
+		fs := flag.NewFlagSet("count", flag.ContinueOnError)
+		v := BoolSet(fs, "verbose", "", "verbosity").MaxOccurrences(2)
+		if err := v.setFrom("2", src, "", DefaultDelimiter); err != nil {
+			t.Errorf("%s: %v", src, err)
+		}
+		if err := v.setFrom("true", src, "", DefaultDelimiter); err != nil {
+			t.Errorf("%s: a second use: %v", src, err)
+		}
+	}
+}
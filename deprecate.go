@@ -0,0 +1,52 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// WarningOutput receives warnings, such as the use of a deprecated flag.
+// It may be changed, or set to nil to discard warnings.
+var WarningOutput io.Writer = os.Stderr
+
+// warnf writes a warning line to WarningOutput.
+func warnf(format string, a ...interface{}) {
+	if WarningOutput != nil {
+		fmt.Fprintln(WarningOutput, fmt.Sprintf(messages.Warning, fmt.Sprintf(format, a...)))
+	}
+}
+
+// deprecationText returns the description of a deprecation with an optional reason.
+func deprecationText(reason string) string {
+	if reason == "" {
+		return messages.Deprecated
+	}
+	return fmt.Sprintf(messages.DeprecatedReason, reason)
+}
+
+// Deprecate marks v as deprecated for reason, which may be empty or may suggest a replacement.
+// Usage output marks the flag as deprecated and each use of the flag, under any of its names,
+// writes a warning to WarningOutput. The flag is otherwise unaffected.
+// Deprecate returns v to permit chaining.
+func (v *Value) Deprecate(reason string) *Value {
+	v.deprecated = true
+	v.deprecation = reason
+	return v
+}
+
+// DeprecateAlias marks the alias of v as deprecated for reason, which may be empty.
+// Usage output marks the alias as deprecated and each use of the alias writes a warning
+// to WarningOutput. The alias is otherwise unaffected.
+// DeprecateAlias returns v to permit chaining.
+func (v *Value) DeprecateAlias(alias string, reason string) *Value {
+	if v.deprecatedAliases == nil {
+		v.deprecatedAliases = make(map[string]string)
+	}
+	v.deprecatedAliases[alias] = reason
+	return v
+}
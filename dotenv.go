@@ -0,0 +1,72 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// parseDotEnv reads KEY=value lines from r. Blank lines, lines starting with # and a leading
+// "export " are ignored. A value enclosed in single or double quotes has them removed.
+// If a key is repeated, the last value is used.
+func parseDotEnv(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, err := splitKeyValue(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars, scanner.Err()
+}
+
+// ApplyDotEnv reads KEY=value lines, in the style of a .env file, from r and applies them to
+// the multiflag values registered in fs as BindEnv would. A variable that is also set in the
+// environment is ignored, so that the environment takes precedence over the file.
+func ApplyDotEnv(fs *flag.FlagSet, prefix string, r io.Reader) error {
+	vars, err := parseDotEnv(r)
+	if err != nil {
+		return err
+	}
+	return bindEnv(fs, prefix, func(key string) (string, bool) {
+		if _, ok := os.LookupEnv(key); ok {
+			return "", false
+		}
+		s, ok := vars[key]
+		return s, ok
+	})
+}
+
+// LoadDotEnv reads the .env file at path and applies it to fs with ApplyDotEnv.
+// Use flag.CommandLine for values created with the package level constructors.
+func LoadDotEnv(fs *flag.FlagSet, prefix string, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := ApplyDotEnv(fs, prefix, f); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+)
+
+// contains reports whether list contains s.
+func contains(list []string, s string) bool {
+	for _, elem := range list {
+		if elem == s {
+			return true
+		}
+	}
+	return false
+}
+
+// WithChoices restricts the arguments of v to choices, which are shown in usage output
+// and offered as candidates by completion. WithChoices panics if a default value of v is not among choices.
+// WithChoices returns v to permit chaining.
+func (v *Value) WithChoices(choices ...string) *Value {
+	v.choices = append([]string{}, choices...)
+	v.checkDefaults()
+	return v
+}
+
+func newEnum(fn Flagger, name string, value string, usage string, choices []string, aliases ...string) *Value {
+	return newString(fn, name, value, usage, aliases...).WithChoices(choices...)
+}
+
+// Enum returns a string multiflag instance, associated with flag, whose arguments must be among choices.
+// See WithChoices.
+func Enum(name string, value string, usage string, choices []string, aliases ...string) *Value {
+	return newEnum(flag.Var, name, value, usage, choices, aliases...)
+}
+
+// EnumSet creates an Enum multiflag instance, associates it with the provided FlagSet and returns it.
+func EnumSet(flg *flag.FlagSet, name string, value string, usage string, choices []string, aliases ...string) *Value {
+	return newEnum(flg.Var, name, value, usage, choices, aliases...)
+}
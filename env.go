@@ -0,0 +1,71 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvName returns the environment variable name for a flag:
+// the prefix and flag name joined by an underscore, uppercased, with dashes and dots replaced by underscores.
+func EnvName(prefix string, name string) string {
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// EnvDelimiter, if not zero, is the delimiter used to split environment variable values
+// for non-boolean flags that have not been configured with Split, WithDelimiter, PathList,
+// NoSplit or WithEnvDelimiter. Escaping and quoting rules are those described for WithDelimiter.
+var EnvDelimiter rune = ','
+
+// WithEnvDelimiter causes environment variable values for v to be split on sep,
+// so that, for instance, TRACE=parse:compile is collected as two values.
+// It has no effect if v is configured with its own delimiter, with PathList or with NoSplit.
+// WithEnvDelimiter returns v to permit chaining.
+func (v *Value) WithEnvDelimiter(sep rune) *Value {
+	v.envSep = sep
+	return v
+}
+
+// setEnv adds the value of the environment variable key to v.
+func (v *Value) setEnv(key string, s string) error {
+	sep := v.envSep
+	if sep == 0 {
+		sep = EnvDelimiter
+	}
+	return v.setFrom(s, SourceEnv, key, sep)
+}
+
+// BindEnv applies environment variables to the multiflag values registered in fs.
+// The variable for each value is named by EnvName(prefix, name), where name is the flag name; aliases are not consulted.
+// BindEnv may be called before or after fs.Parse; the combination of environment and command line
+// arguments is determined by DefaultPrecedence.
+// Environment values are split as described for EnvDelimiter and do not cause IsSet to return true.
+// Use flag.CommandLine for values created with the package level constructors.
+func BindEnv(fs *flag.FlagSet, prefix string) error {
+	return bindEnv(fs, prefix, os.LookupEnv)
+}
+
+// bindEnv applies the variables found by lookup to the multiflag values registered in fs.
+func bindEnv(fs *flag.FlagSet, prefix string, lookup func(string) (string, bool)) error {
+	var err error
+	visit(fs, func(v *Value) {
+		if err != nil {
+			return
+		}
+		key := EnvName(prefix, v.name)
+		if s, ok := lookup(key); ok {
+			if e := v.setEnv(key, s); e != nil {
+				err = fmt.Errorf(messages.InvalidEnv, s, key, e)
+			}
+		}
+	})
+	return err
+}
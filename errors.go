@@ -0,0 +1,43 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"fmt"
+)
+
+// FlagError describes an invalid argument for a flag, or a failed constraint on a flag.
+// It is the type of the errors reported by Parse, ApplyConfig and Validate for multiflag values.
+type FlagError struct {
+	Name     string // flag name
+	Used     string // name, possibly an alias, under which the flag was given
+	Value    string // the argument, if HasValue
+	HasValue bool   // denotes an error in an argument, rather than a failed constraint
+	Err      error  // the cause
+}
+
+// Error returns the text of e, as produced by FormatError.
+func (e *FlagError) Error() string {
+	return FormatError(e)
+}
+
+// Unwrap returns the cause of e.
+func (e *FlagError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorFormatter produces the text of a FlagError.
+type ErrorFormatter func(e *FlagError) string
+
+// FormatError produces the text of each FlagError.
+// The function is a variable that may be changed to point to a custom function of type ErrorFormatter,
+// so that errors can be rephrased or localized consistently. By default, it formats the InvalidValue
+// or FlagFailed message of the catalog selected by SetLocale, with the name that was used.
+var FormatError ErrorFormatter = func(e *FlagError) string {
+	if e.HasValue {
+		return fmt.Sprintf(messages.InvalidValue, e.Value, e.Used, e.Err)
+	}
+	return fmt.Sprintf(messages.FlagFailed, e.Used, e.Err)
+}
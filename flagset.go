@@ -0,0 +1,60 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"strings"
+)
+
+// valueOf returns the multiflag Value registered as f, under its name or an alias, if any.
+func valueOf(f *flag.Flag) (*Value, bool) {
+	return ValueOf(f.Value)
+}
+
+// ValueOf returns the multiflag Value that val is or, if val was registered for an alias, that it stands for.
+// It permits a Flagger for another flag package to recognize the aliases of a Value.
+func ValueOf(val flag.Value) (*Value, bool) {
+	switch val := val.(type) {
+	case *Value:
+		return val, true
+	case *alias:
+		return val.Value, true
+	}
+	return nil, false
+}
+
+// visit calls fn once for each multiflag Value registered in fs, in lexicographical order
+// of the first name under which it is found. Aliases do not cause repeated calls.
+func visit(fs *flag.FlagSet, fn func(v *Value)) {
+	seen := make(map[*Value]bool)
+	fs.VisitAll(func(f *flag.Flag) {
+		if v, ok := valueOf(f); ok && !seen[v] {
+			seen[v] = true
+			fn(v)
+		}
+	})
+}
+
+// Reset calls Reset on each multiflag Value registered in fs.
+// Use flag.CommandLine for values created with the package level constructors.
+func Reset(fs *flag.FlagSet) {
+	visit(fs, func(v *Value) { v.Reset() })
+}
+
+// Lookup returns the multiflag Value registered in fs under nameOrAlias, which may have leading dashes,
+// and the name of the flag, which differs from nameOrAlias if it is an alias.
+// It reports false if there is no such flag, or if it is not a multiflag Value.
+func Lookup(fs *flag.FlagSet, nameOrAlias string) (*Value, string, bool) {
+	f := fs.Lookup(strings.TrimLeft(nameOrAlias, "-"))
+	if f == nil {
+		return nil, "", false
+	}
+	v, ok := valueOf(f)
+	if !ok {
+		return nil, "", false
+	}
+	return v, v.name, true
+}
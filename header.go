@@ -0,0 +1,62 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// splitHeader splits a "Name: value" or "Name=value" argument.
+func splitHeader(s string) (name, value string, err error) {
+	i := strings.IndexAny(s, ":=")
+	if i < 0 {
+		return "", "", fmt.Errorf("%q is not of the form Name: value", s)
+	}
+	name = strings.TrimSpace(s[:i])
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", fmt.Errorf("%q has an invalid header name", s)
+	}
+	return name, strings.TrimSpace(s[i+1:]), nil
+}
+
+// HeaderValue collects repeated HTTP header flag arguments.
+type HeaderValue struct {
+	*Value
+}
+
+func newHeader(fn Flagger, name string, value string, usage string, aliases ...string) *HeaderValue {
+	h := &HeaderValue{Value: newString(fn, name, value, usage, aliases...)}
+	h.check = func(s string) error {
+		_, _, err := splitHeader(s)
+		return err
+	}
+	h.checkDefaults()
+	return h
+}
+
+// Header returns an HTTP header multiflag instance associated with flag.
+// Each argument must be of the form "Name: value" or "Name=value".
+func Header(name string, value string, usage string, aliases ...string) *HeaderValue {
+	return newHeader(flag.Var, name, value, usage, aliases...)
+}
+
+// HeaderSet creates a Header multiflag instance, associates it with the provided FlagSet and returns it.
+func HeaderSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *HeaderValue {
+	return newHeader(flg.Var, name, value, usage, aliases...)
+}
+
+// Header returns the collected headers, with canonicalized names.
+func (h *HeaderValue) Header() http.Header {
+	out := make(http.Header)
+	for _, arg := range h.Args() {
+		if name, value, err := splitHeader(arg); err == nil {
+			out.Add(name, value)
+		}
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// parseINI reads an INI document into a map of flag names to arrays of values.
+// A key in a section names the flag section-key; a key before any section names the flag key.
+// Blank lines and lines starting with ; or # are ignored.
+// A value enclosed in double quotes has them removed.
+func parseINI(r io.Reader) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || line[0] == ';' || line[0] == '#':
+			continue
+		case line[0] == '[':
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section header", n)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		key, value, err := splitKeyValue(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		key = strings.TrimSpace(key)
+		if section != "" {
+			key = section + "-" + key
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+
+		list, _ := values[key].([]interface{})
+		values[key] = append(list, value)
+	}
+	return values, scanner.Err()
+}
+
+// ApplyINI reads an INI document from r and applies it to fs with ApplyConfig.
+// A key in a section, as in
+//
+//	[log]
+//	level = debug
+//
+// sets the flag section-key, here log-level. Keys before the first section set the flag of the same name.
+// A repeated key sets the flag repeatedly.
+func ApplyINI(fs *flag.FlagSet, r io.Reader) error {
+	return applyINI(fs, "", r)
+}
+
+func applyINI(fs *flag.FlagSet, origin string, r io.Reader) error {
+	values, err := parseINI(r)
+	if err != nil {
+		return err
+	}
+	return ApplyConfigFrom(fs, origin, values)
+}
+
+// LoadINI reads the INI file at path and applies it to fs with ApplyINI.
+// Use flag.CommandLine for values created with the package level constructors.
+func LoadINI(fs *flag.FlagSet, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := applyINI(fs, path, f); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// Stdin is read for arguments given as "-"; see ReadStdin. It may be changed, as for testing.
+var Stdin io.Reader = os.Stdin
+
+// Input determines how the content read for an argument, such as from standard input or a file, becomes values.
+type Input int
+
+const (
+	OneLine    Input = iota // the first line, without its line ending
+	AllContent              // the entire content, without a final line ending
+	EachLine                // each line that is not empty, without its line ending
+)
+
+// ReadStdin causes an argument of "-", on the command line, to be replaced by content read from Stdin,
+// as given by mode, so that a secret or a large value need not appear in the command line.
+// Each "-" with mode OneLine reads the next line. The content is not split.
+// ReadStdin returns v to permit chaining.
+func (v *Value) ReadStdin(mode Input) *Value {
+	v.stdin, v.stdinMode = true, mode
+	return v
+}
+
+// ReadFiles causes an argument that begins with @, on the command line, as in -header @headers.txt,
+// to be replaced by the content of the named file, as given by mode, so that long or secret values
+// need not appear in the command line or the shell history. The content is not split.
+// An argument that begins with @@ stands for itself without the first @.
+// ReadFiles returns v to permit chaining.
+func (v *Value) ReadFiles(mode Input) *Value {
+	v.files, v.fileMode = true, mode
+	return v
+}
+
+// expand returns the values given by a command line argument, which are read from Stdin
+// or a file if v reads them from there, and are otherwise obtained by splitting s.
+func (v *Value) expand(s string) ([]string, error) {
+	if v.stdin && s == "-" {
+		return readInput(Stdin, v.stdinMode)
+	}
+	if v.files && strings.HasPrefix(s, "@") {
+		if strings.HasPrefix(s, "@@") {
+			return v.split(s[1:])
+		}
+		f, err := os.Open(s[1:])
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return readInput(f, v.fileMode)
+	}
+	return v.split(s)
+}
+
+// readInput reads values from r as given by mode.
+func readInput(r io.Reader, mode Input) ([]string, error) {
+	if mode == OneLine {
+		line, err := readLine(r)
+		return []string{line}, err
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if mode == EachLine {
+		var lines []string
+		for _, line := range strings.Split(string(b), "\n") {
+			if line = trimEOL(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines, nil
+	}
+	return []string{trimEOL(string(b))}, nil
+}
+
+// readLine reads a line from r, a byte at a time so that no more is consumed.
+// It reports io.ErrUnexpectedEOF if r is exhausted.
+func readLine(r io.Reader) (string, error) {
+	var b strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+			b.WriteByte(buf[0])
+		}
+		if err == io.EOF {
+			if b.Len() == 0 {
+				return "", io.ErrUnexpectedEOF
+			}
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\r"), nil
+}
+
+// trimEOL removes a final line ending from s.
+func trimEOL(s string) string {
+	s = strings.TrimSuffix(s, "\n")
+	return strings.TrimSuffix(s, "\r")
+}
@@ -0,0 +1,78 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+// keepMode determines which of the collected arguments are returned by Args.
+type keepMode int
+
+const (
+	keepAll   keepMode = iota // all arguments
+	keepLast                  // the last argument
+	keepFirst                 // the first argument
+)
+
+// KeepLast causes Args, and the accessors based on it, to return only the last argument,
+// so that v behaves like an ordinary flag whose value may be overridden later on the command line,
+// as when a shell alias supplies a default. NArg still counts every argument.
+// KeepLast returns v to permit chaining.
+func (v *Value) KeepLast() *Value {
+	v.keep = keepLast
+	return v
+}
+
+// KeepFirst causes Args, and the accessors based on it, to return only the first argument,
+// ignoring later ones, for flags whose earliest setting is authoritative. NArg still counts every argument.
+// If warn is true, each ignored argument, among those from the same source, such as the command line,
+// causes a warning to be written to WarningOutput.
+// KeepFirst returns v to permit chaining.
+func (v *Value) KeepFirst(warn bool) *Value {
+	v.keep = keepFirst
+	v.warnIgnored = warn
+	return v
+}
+
+// kept returns the arguments, of those in args, selected by the keepMode of v.
+func (v *Value) kept(args []string) []string {
+	if len(args) > 1 {
+		switch v.keep {
+		case keepLast:
+			return args[len(args)-1:]
+		case keepFirst:
+			return args[:1]
+		}
+	}
+	return args
+}
+
+// WithReset makes each of tokens, given as an argument of v, as in -t none or -t -, discard the arguments
+// collected before it from the same or a lower ranked source, so that a wrapping script can replace a list
+// supplied by an outer layer, such as a configuration file or an earlier part of the command line.
+// The tokens themselves are not collected, and are exempt from validation.
+// WithReset returns v to permit chaining.
+func (v *Value) WithReset(tokens ...string) *Value {
+	v.resets = append(v.resets, tokens...)
+	return v
+}
+
+// outranked returns the occurrences in occs from sources that rank above src.
+func outranked(occs []Occurrence, src Source) []Occurrence {
+	var kept []Occurrence
+	for _, o := range occs {
+		if o.Source.rank() > src.rank() {
+			kept = append(kept, o)
+		}
+	}
+	return kept
+}
+
+// AssignReplaces causes an argument given in the form -name=value, as in -tags=a,b, to replace the arguments
+// collected before it from the same or a lower ranked source, while one given in the form -name value,
+// as in -tags c, is added to them. A single command line can thus both extend and override the values
+// from defaults, configuration files and the environment. The forms are distinguished only by Parse.
+// AssignReplaces returns v to permit chaining.
+func (v *Value) AssignReplaces() *Value {
+	v.assignReplaces = true
+	return v
+}
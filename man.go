@@ -0,0 +1,107 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// roff escapes s for use in roff text.
+func roff(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roffFlag formats a flag name in bold.
+func roffFlag(name string) string {
+	return `\fB` + roff("-"+name) + `\fR`
+}
+
+// capitalize returns s with its first letter in upper case.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+// writeManEntries writes a tagged paragraph for each flag, followed by one for each alias
+// referring to its flag and one for each negating flag.
+func writeManEntries(b *strings.Builder, flags []FlagInfo) {
+	type alias struct{ alias, name string }
+	var aliases, negations []alias
+	for _, info := range flags {
+		for _, n := range info.Negations {
+			negations = append(negations, alias{n, info.Name})
+		}
+		names := []string{roffFlag(info.Name)}
+		for _, a := range info.Aliases {
+			names = append(names, roffFlag(a))
+			aliases = append(aliases, alias{a, info.Name})
+		}
+		tag := strings.Join(names, ", ")
+		if info.Placeholder != "" {
+			tag += ` \fI` + roff(info.Placeholder) + `\fR`
+		}
+		fmt.Fprintf(b, ".TP\n%s\n%s\n", tag, roff(info.Usage))
+		if info.Repeatable {
+			fmt.Fprintf(b, ".br\n%s\n", messages.ManRepeatable)
+		}
+		if len(info.Choices) > 0 {
+			fmt.Fprintf(b, ".br\n%s\n", fmt.Sprintf(messages.ManChoices, roff(strings.Join(info.Choices, ", "))))
+		}
+		if info.Pattern != "" {
+			fmt.Fprintf(b, ".br\n%s\n", fmt.Sprintf(messages.ManPattern, roff(info.Pattern)))
+		}
+		if info.Required {
+			fmt.Fprintf(b, ".br\n%s\n", messages.ManRequired)
+		}
+		if info.Default != "" {
+			fmt.Fprintf(b, ".br\n%s\n", fmt.Sprintf(messages.ManDefault, roff(info.Default)))
+		}
+		for _, example := range info.Examples {
+			fmt.Fprintf(b, ".br\n%s\n", fmt.Sprintf(messages.Example, `\fB`+roff(example)+`\fR`))
+		}
+		for _, note := range info.deprecationNotes() {
+			fmt.Fprintf(b, ".br\n%s.\n", roff(capitalize(note)))
+		}
+	}
+	for _, a := range aliases {
+		fmt.Fprintf(b, ".TP\n%s\n%s\n", roffFlag(a.alias), fmt.Sprintf(messages.ManAlias, roffFlag(a.name)))
+	}
+	for _, n := range negations {
+		fmt.Fprintf(b, ".TP\n%s\n%s.\n", roffFlag(n.alias), fmt.Sprintf(messages.Negates, roffFlag(n.name)))
+	}
+}
+
+// WriteMan writes, to w, the OPTIONS section of a man page, in roff, describing the flags in fs.
+// Each flag is followed by its aliases, if any, and each alias also has an entry that refers to its flag,
+// as does each negating flag, such as -no-verbose.
+// Flags in a group are written in a subsection named for the group.
+func WriteMan(fs *flag.FlagSet, w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(".SH OPTIONS\n")
+	ungrouped, groups, byGroup := groupFlags(Flags(fs))
+	writeManEntries(&b, ungrouped)
+	for _, group := range groups {
+		fmt.Fprintf(&b, ".SS %s\n", roff(group))
+		writeManEntries(&b, byGroup[group])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
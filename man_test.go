@@ -0,0 +1,60 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestDocumentationLocalized(t *testing.T) {
+	RegisterMessages("xx", Messages{
+		Repeatable:    "(répétable)",
+		Required:      "(obligatoire)",
+		Choices:       "(parmi %s)",
+		ManRepeatable: "Répétable.",
+		ManChoices:    "Parmi : %s.",
+		ManRequired:   "Obligatoire.",
+		ManDefault:    "Par défaut : %s.",
+		ManAlias:      "Comme %s.",
+		TableHeader:   "Option | Alias | Type | Défaut | Répétable | Description",
+		Yes:           "oui",
+		No:            "non",
+	})
+	if err := SetLocale("xx"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("en")
+
+	fs := flag.NewFlagSet("doc", flag.ContinueOnError)
+	StringSet(fs, "mode", "fast", "mode", "m").WithChoices("fast", "slow").Required()
+	BoolSet(fs, "verbose", "", "verbosity").Deprecate("écrasé par -log")
+
+	var man, md bytes.Buffer
+	if err := WriteMan(fs, &man); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteMarkdown(fs, &md); err != nil {
+		t.Fatal(err)
+	}
+	for _, english := range []string{"May be repeated", "One of", "Required", "Default", "Same as", "Repeatable", "| yes |", "| no |"} {
+		if strings.Contains(man.String(), english) || strings.Contains(md.String(), english) {
+			t.Errorf("%q in localized output:\n%s\n%s", english, man.String(), md.String())
+		}
+	}
+	if !strings.Contains(man.String(), "Comme \\fB\\-mode\\fR.") {
+		t.Errorf("alias entry not localized:\n%s", man.String())
+	}
+}
+
+func TestCapitalize(t *testing.T) {
+	for s, want := range map[string]string{"": "", "déprécié": "Déprécié", "élimé": "Élimé"} {
+		if got := capitalize(s); got != want {
+			t.Errorf("capitalize(%q): got %q, want %q", s, got, want)
+		}
+	}
+}
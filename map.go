@@ -0,0 +1,232 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duplicates determines how a map flag treats a key that is given more than once.
+type Duplicates int
+
+const (
+	LastWins         Duplicates = iota // a later value replaces an earlier one
+	FirstWins                          // later values are ignored
+	RejectDuplicates                   // a repeated key is a parse error
+)
+
+// splitKeyValue splits a key=value argument.
+func splitKeyValue(s string) (key, value string, err error) {
+	i := strings.Index(s, "=")
+	if i < 0 {
+		return "", "", fmt.Errorf(messages.NotKeyValue, s)
+	}
+	return s[:i], s[i+1:], nil
+}
+
+// StringMapValue collects repeated key=value flag arguments into a map.
+type StringMapValue struct {
+	*Value
+	dup Duplicates
+}
+
+// checkKeyValue causes v to accept only key=value arguments. If parse is not nil, it must also accept the value.
+// Under RejectDuplicates, the keys of the arguments of each use, such as the values of a split argument,
+// must differ from each other and from those already collected from the same source, since those
+// from other sources are combined according to Precedence.
+func checkKeyValue(v *Value, dup Duplicates, parse func(string) error) {
+	v.check = func(s string) error {
+		key, value, err := splitKeyValue(s)
+		if err != nil {
+			return err
+		}
+		if parse != nil {
+			if err := parse(value); err != nil {
+				return fmt.Errorf("key %q: %v", key, err)
+			}
+		}
+		return nil
+	}
+	if dup == RejectDuplicates {
+		v.checkBatch = func(args []string) error {
+			seen := make(map[string]bool)
+			for _, arg := range args {
+				key, _, err := splitKeyValue(arg)
+				if err != nil {
+					continue
+				}
+				if seen[key] {
+					return fmt.Errorf(messages.DuplicateKey, key)
+				}
+				seen[key] = true
+			}
+			return nil
+		}
+	}
+}
+
+// eachKeyValue calls fn, in order, with each key and value in args that is retained under dup.
+func eachKeyValue(args []string, dup Duplicates, fn func(key, value string)) {
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		key, value, err := splitKeyValue(arg)
+		if err != nil || (seen[key] && dup != LastWins) {
+			continue
+		}
+		seen[key] = true
+		fn(key, value)
+	}
+}
+
+func newStringMap(fn Flagger, name string, value string, usage string, dup Duplicates, aliases ...string) *StringMapValue {
+	m := &StringMapValue{Value: newString(fn, name, value, usage, aliases...), dup: dup}
+	checkKeyValue(m.Value, dup, nil)
+	m.checkDefaults()
+	return m
+}
+
+// StringMap returns a map multiflag instance associated with flag.
+// Each argument must be of the form key=value; dup determines the treatment of repeated keys.
+func StringMap(name string, value string, usage string, dup Duplicates, aliases ...string) *StringMapValue {
+	return newStringMap(flag.Var, name, value, usage, dup, aliases...)
+}
+
+// StringMapSet creates a StringMap multiflag instance, associates it with the provided FlagSet and returns it.
+func StringMapSet(flg *flag.FlagSet, name string, value string, usage string, dup Duplicates, aliases ...string) *StringMapValue {
+	return newStringMap(flg.Var, name, value, usage, dup, aliases...)
+}
+
+// Map returns the collected keys and values.
+func (m *StringMapValue) Map() map[string]string {
+	out := make(map[string]string)
+	eachKeyValue(m.Args(), m.dup, func(key, value string) {
+		out[key] = value
+	})
+	return out
+}
+
+// MultiMapValue collects repeated key=value flag arguments into a map whose values accumulate per key.
+type MultiMapValue struct {
+	*Value
+}
+
+func newMultiMap(fn Flagger, name string, value string, usage string, aliases ...string) *MultiMapValue {
+	m := &MultiMapValue{Value: newString(fn, name, value, usage, aliases...)}
+	checkKeyValue(m.Value, LastWins, nil)
+	m.checkDefaults()
+	return m
+}
+
+// MultiMap returns a multi-valued map multiflag instance associated with flag.
+// Each argument must be of the form key=value; values for a repeated key are accumulated.
+func MultiMap(name string, value string, usage string, aliases ...string) *MultiMapValue {
+	return newMultiMap(flag.Var, name, value, usage, aliases...)
+}
+
+// MultiMapSet creates a MultiMap multiflag instance, associates it with the provided FlagSet and returns it.
+func MultiMapSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *MultiMapValue {
+	return newMultiMap(flg.Var, name, value, usage, aliases...)
+}
+
+// Map returns the collected keys, each with its values in the order they were given.
+func (m *MultiMapValue) Map() map[string][]string {
+	out := make(map[string][]string)
+	for _, arg := range m.Args() {
+		if key, value, err := splitKeyValue(arg); err == nil {
+			out[key] = append(out[key], value)
+		}
+	}
+	return out
+}
+
+// Keys returns the collected keys, without duplicates, in the order they were first given.
+func (m *MultiMapValue) Keys() []string {
+	seen := make(map[string]bool)
+	keys := []string{}
+	for _, arg := range m.Args() {
+		if key, _, err := splitKeyValue(arg); err == nil && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IntMapValue collects repeated key=value flag arguments, with integer values, into a map.
+type IntMapValue struct {
+	*Value
+	dup Duplicates
+}
+
+func newIntMap(fn Flagger, name string, value string, usage string, dup Duplicates, aliases ...string) *IntMapValue {
+	m := &IntMapValue{Value: newString(fn, name, value, usage, aliases...), dup: dup}
+	checkKeyValue(m.Value, dup, func(s string) error {
+		_, err := strconv.Atoi(s)
+		return err
+	})
+	m.checkDefaults()
+	return m
+}
+
+// IntMap returns an integer map multiflag instance associated with flag.
+// Each argument must be of the form key=value, where value is an integer; dup determines the treatment of repeated keys.
+func IntMap(name string, value string, usage string, dup Duplicates, aliases ...string) *IntMapValue {
+	return newIntMap(flag.Var, name, value, usage, dup, aliases...)
+}
+
+// IntMapSet creates an IntMap multiflag instance, associates it with the provided FlagSet and returns it.
+func IntMapSet(flg *flag.FlagSet, name string, value string, usage string, dup Duplicates, aliases ...string) *IntMapValue {
+	return newIntMap(flg.Var, name, value, usage, dup, aliases...)
+}
+
+// Map returns the collected keys and values.
+func (m *IntMapValue) Map() map[string]int {
+	out := make(map[string]int)
+	eachKeyValue(m.Args(), m.dup, func(key, value string) {
+		out[key], _ = strconv.Atoi(value)
+	})
+	return out
+}
+
+// DurationMapValue collects repeated key=value flag arguments, with time.Duration values, into a map.
+type DurationMapValue struct {
+	*Value
+	dup Duplicates
+}
+
+func newDurationMap(fn Flagger, name string, value string, usage string, dup Duplicates, aliases ...string) *DurationMapValue {
+	m := &DurationMapValue{Value: newString(fn, name, value, usage, aliases...), dup: dup}
+	checkKeyValue(m.Value, dup, func(s string) error {
+		_, err := time.ParseDuration(s)
+		return err
+	})
+	m.checkDefaults()
+	return m
+}
+
+// DurationMap returns a duration map multiflag instance associated with flag.
+// Each argument must be of the form key=value, where value is accepted by time.ParseDuration;
+// dup determines the treatment of repeated keys.
+func DurationMap(name string, value string, usage string, dup Duplicates, aliases ...string) *DurationMapValue {
+	return newDurationMap(flag.Var, name, value, usage, dup, aliases...)
+}
+
+// DurationMapSet creates a DurationMap multiflag instance, associates it with the provided FlagSet and returns it.
+func DurationMapSet(flg *flag.FlagSet, name string, value string, usage string, dup Duplicates, aliases ...string) *DurationMapValue {
+	return newDurationMap(flg.Var, name, value, usage, dup, aliases...)
+}
+
+// Map returns the collected keys and values.
+func (m *DurationMapValue) Map() map[string]time.Duration {
+	out := make(map[string]time.Duration)
+	eachKeyValue(m.Args(), m.dup, func(key, value string) {
+		out[key], _ = time.ParseDuration(value)
+	})
+	return out
+}
@@ -0,0 +1,60 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// env returns a lookup function, for bindEnv, that finds the variables in vars.
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		s, ok := vars[key]
+		return s, ok
+	}
+}
+
+func TestRejectDuplicates(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		args []string
+		want map[string]string
+		err  string
+	}{
+		{name: "distinct", args: []string{"-define", "a=1", "-define", "b=2"}, want: map[string]string{"a": "1", "b": "2"}},
+		{name: "repeated", args: []string{"-define", "a=1", "-define", "a=2"}, err: `duplicate key "a"`},
+		{name: "repeated in split argument", args: []string{"-define", "a=1,a=2"}, err: `duplicate key "a"`},
+		{name: "repeated in environment", env: map[string]string{"APP_DEFINE": "a=1,a=2"}, err: `duplicate key "a"`},
+		{name: "environment and command line", env: map[string]string{"APP_DEFINE": "a=1"}, args: []string{"-define", "a=2"}, want: map[string]string{"a": "2"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("map", flag.ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+			m := StringMapSet(fs, "define", "", "definitions", RejectDuplicates)
+			m.Split()
+			err := bindEnv(fs, "APP", env(tc.env))
+			if err == nil {
+				err = Parse(fs, tc.args)
+			}
+			switch {
+			case tc.err != "":
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("got error %v, want %q", err, tc.err)
+				}
+			case err != nil:
+				t.Errorf("unexpected error: %v", err)
+			default:
+				if got := m.Map(); !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownCell escapes s for use in a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// markdownCode formats names as a comma separated list of code spans.
+func markdownCode(names []string) string {
+	codes := make([]string, len(names))
+	for i, name := range names {
+		codes[i] = "`" + name + "`"
+	}
+	return strings.Join(codes, ", ")
+}
+
+// writeMarkdownTable writes a Markdown table describing flags.
+func writeMarkdownTable(w io.Writer, flags []FlagInfo) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "| %s |\n", messages.TableHeader)
+	b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, info := range flags {
+		aliases := make([]string, len(info.Aliases))
+		for i, alias := range info.Aliases {
+			aliases[i] = "-" + alias
+		}
+		name := "-" + info.Name
+		if info.Placeholder != "" {
+			name += " " + info.Placeholder
+		}
+		dflt := ""
+		if info.Default != "" {
+			dflt = "`" + info.Default + "`"
+		}
+		repeatable := messages.No
+		if info.Repeatable {
+			repeatable = messages.Yes
+		}
+		desc := info.Usage
+		if len(info.Choices) > 0 {
+			desc += " " + fmt.Sprintf(messages.Choices, markdownCode(info.Choices))
+		}
+		if info.Pattern != "" {
+			desc += " " + fmt.Sprintf(messages.Pattern, "`"+info.Pattern+"`")
+		}
+		if info.Required {
+			desc += " _" + messages.Required + "_"
+		}
+		if len(info.Negations) > 0 {
+			names := make([]string, len(info.Negations))
+			for i, name := range info.Negations {
+				names[i] = "-" + name
+			}
+			desc += " " + fmt.Sprintf(messages.Negation, markdownCode(names))
+		}
+		for _, note := range info.deprecationNotes() {
+			desc += " _(" + note + ")_"
+		}
+		for _, example := range info.Examples {
+			desc += "<br>" + fmt.Sprintf(messages.Example, "`"+example+"`")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", markdownCode([]string{name}), markdownCode(aliases),
+			info.Type, markdownCell(dflt), repeatable, markdownCell(desc))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMarkdown writes, to w, Markdown tables describing the flags in fs: their names, aliases,
+// types, defaults, repeatability and usage, with any examples and negating flags. Flags in a group are written in a separate table
+// under a heading naming the group.
+func WriteMarkdown(fs *flag.FlagSet, w io.Writer) error {
+	ungrouped, groups, byGroup := groupFlags(Flags(fs))
+	if len(ungrouped) > 0 {
+		if err := writeMarkdownTable(w, ungrouped); err != nil {
+			return err
+		}
+	}
+	for i, group := range groups {
+		if i > 0 || len(ungrouped) > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "### %s\n\n", group); err != nil {
+			return err
+		}
+		if err := writeMarkdownTable(w, byGroup[group]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
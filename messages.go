@@ -0,0 +1,140 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Messages is a catalog of the text that the package produces in usage output, warnings and errors.
+// Each field is a format for fmt.Sprintf, whose operands are described by the English catalog.
+type Messages struct {
+	Alias             string // usage text of an alias; the flag name
+	Negates           string // usage text of a negating flag; the flag name
+	Negation          string // negating flag annotation; the negating flag names, comma separated
+	Repeatable        string // see RepeatableMarker
+	Default           string // default value annotation; the default value, quoted if it is a string
+	Example           string // usage example line; the example
+	Required          string // required flag annotation
+	Choices           string // permitted values annotation; the values, comma separated
+	Pattern           string // value pattern annotation; the regular expression
+	Deprecated        string // deprecation annotation, without a reason
+	DeprecatedReason  string // deprecation annotation; the reason
+	DeprecatedAlias   string // annotation of a deprecated alias; the alias and the deprecation annotation
+	DeprecatedFlag    string // warning of the use of a deprecated flag; the name and the deprecation annotation
+	Warning           string // warning line; the warning
+	Ignored           string // warning of an argument ignored under KeepFirst; the flag name and the argument
+	Capped            string // warning of a use beyond the limit set by WithMax; the flag name and the limit
+	UndefinedFlag     string // error for an unknown configuration name; the name
+	InvalidValue      string // error for an argument; the value, flag name and error
+	FlagFailed        string // error for a failed constraint; the flag name and error
+	InvalidEnv        string // error for an environment variable; the value, variable name and error
+	NotKeyValue       string // error for a map argument that is not of the form key=value; the argument
+	DuplicateKey      string // error for a key repeated under RejectDuplicates; the key
+	DuplicateValue    string // error for a value repeated under NoDuplicates; the value
+	NoMatch           string // error for a value that does not match its pattern; the value and the regular expression
+	OutOfRange        string // error for a number outside its range; the value, minimum and maximum
+	Empty             string // error for an empty value
+	UnterminatedQuote string // error for an argument with an unterminated quote; the argument
+	InvalidChoice     string // error for a value that is not permitted; the value and the permitted values
+	TooFew            string // error for too few values; the number given and the minimum
+	TooMany           string // error for too many values; the number given and the maximum
+	Missing           string // error for a required flag without values
+	Exclusive         string // error for mutually exclusive flags used together; the names used
+	Requires          string // error for a flag used without one it requires; the names used and the name required
+	ManRepeatable     string // man page note of a repeatable flag
+	ManChoices        string // man page note of the permitted values; the values, comma separated
+	ManPattern        string // man page note of the value pattern; the regular expression
+	ManRequired       string // man page note of a required flag
+	ManDefault        string // man page note of the default value; the value
+	ManAlias          string // man page entry of an alias; the flag name
+	TableHeader       string // Markdown table headings for the flag, aliases, type, default, repeatability and description, separated by |
+	Yes               string // Markdown table entry of a repeatable flag
+	No                string // Markdown table entry of a flag that is not repeatable
+}
+
+// English is the default message catalog, registered for the "en" locale.
+var English = Messages{
+	Alias:             "Alias for %s",
+	Negates:           "Negates %s",
+	Negation:          "(negated by %s)",
+	Repeatable:        "(may be repeated)",
+	Default:           "(default %s)",
+	Example:           "Example: %s",
+	Required:          "(required)",
+	Choices:           "(one of %s)",
+	Pattern:           "(matching %s)",
+	Deprecated:        "deprecated",
+	DeprecatedReason:  "deprecated: %s",
+	DeprecatedAlias:   "-%s is %s",
+	DeprecatedFlag:    "flag -%s is %s",
+	Warning:           "warning: %s",
+	Ignored:           "flag -%s: ignoring %q; the first value is kept",
+	Capped:            "flag -%s: count is limited to %d",
+	UndefinedFlag:     "flag provided but not defined: -%s",
+	InvalidValue:      "invalid value %q for flag -%s: %v",
+	FlagFailed:        "flag -%s: %v",
+	InvalidEnv:        "invalid value %q for environment variable %s: %v",
+	NotKeyValue:       "%q is not of the form key=value",
+	DuplicateKey:      "duplicate key %q",
+	DuplicateValue:    "duplicate value %q",
+	NoMatch:           "%q does not match %s",
+	OutOfRange:        "%s is not in the range %v to %v",
+	Empty:             "empty value",
+	UnterminatedQuote: "unterminated quote in %q",
+	InvalidChoice:     "%q is not one of %s",
+	TooFew:            "too few values: %d given, at least %d required",
+	TooMany:           "too many values: %d given, at most %d permitted",
+	Missing:           "required but not given",
+	Exclusive:         "flags %s cannot be used together",
+	Requires:          "flag %s requires -%s",
+	ManRepeatable:     "May be repeated.",
+	ManChoices:        "One of: %s.",
+	ManPattern:        "Matching: %s",
+	ManRequired:       "Required.",
+	ManDefault:        "Default: %s.",
+	ManAlias:          "Same as %s.",
+	TableHeader:       "Flag | Aliases | Type | Default | Repeatable | Description",
+	Yes:               "yes",
+	No:                "no",
+}
+
+// catalogs holds the registered message catalogs by locale.
+var catalogs = map[string]Messages{"en": English}
+
+// messages is the catalog in use; see SetLocale.
+var messages = English
+
+// RegisterMessages registers the catalog m for locale, such as "fr" or "pt_BR".
+// Fields of m that are empty are taken from English.
+func RegisterMessages(locale string, m Messages) {
+	dst, src := reflect.ValueOf(&m).Elem(), reflect.ValueOf(English)
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Field(i).String() == "" {
+			dst.Field(i).SetString(src.Field(i).String())
+		}
+	}
+	catalogs[locale] = m
+}
+
+// SetLocale selects the message catalog registered for locale, or, failing that, for its language,
+// so that "fr_CA.UTF-8" selects the catalog for "fr_CA" or "fr". It also sets RepeatableMarker
+// from the catalog. Since usage text is produced as values are created, SetLocale should be called first.
+// SetLocale returns an error, and changes nothing, if there is no catalog for locale.
+func SetLocale(locale string) error {
+	locale = strings.SplitN(locale, ".", 2)[0]
+	m, ok := catalogs[locale]
+	if !ok {
+		m, ok = catalogs[strings.SplitN(locale, "_", 2)[0]]
+	}
+	if !ok {
+		return fmt.Errorf("no messages for locale %q", locale)
+	}
+	messages = m
+	RepeatableMarker = m.Repeatable
+	return nil
+}
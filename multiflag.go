@@ -64,9 +64,9 @@ It has the following usage text:
 
   Usage of main:
 	-t=none: Alias for trace
-	-trace=none: Trace program sections
+	-trace=none: Trace program sections (may be repeated)
 	-v=false: Alias for verbose
-	-verbose=false: Verbosity. Repeat as necessary
+	-verbose=false: Verbosity. Repeat as necessary (may be repeated)
 
 multiflag also works with *flag.FlagSet instances. The previous example would require the following
 changes:
@@ -76,18 +76,82 @@ changes:
 	var verbosity = multiflag.BoolSet(fs, "verbose", "false", "Verbosity. Repeat as necessary", "v")
 	var trace = multiflag.StringSet(fs, "trace", "none", "Trace program sections", "t")
 
+multiflag depends only on the standard library. Support that requires other modules is in subpackages,
+which a program imports only if it needs them: multiflagpflag, multiflagcobra, multiflagkingpin, multiflagviper
+and multiflagff for other flag packages, tomlcfg and yamlcfg for configuration formats, and termwidth
+for the width of the terminal.
+
 */
 package multiflag
 
 import (
 	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 // Value counts and collects repeated uses of a flag.
 type Value struct {
-	args   []string // collected flag arguments
-	val    string   // default value to display in help
-	isBool bool     // denotes if Value represent a boolean value
+	name              string               // name under which the flag is registered
+	seq               int                  // order of registration, from one
+	aliases           []string             // alternate names for the flag
+	flagger           Flagger              // registers the flag and its aliases
+	negations         []string             // names of negating flags; see Negatable
+	negateZero        bool                 // denotes if a negating flag resets the count to zero, rather than decrementing it
+	usage             string               // usage text, as given
+	group             string               // usage output section, if any
+	examples          []string             // example invocations for usage output
+	hideAliases       bool                 // denotes if aliases are omitted from usage output
+	hidden            bool                 // denotes if the flag is omitted from usage output
+	deprecated        bool                 // denotes if the flag is deprecated
+	deprecation       string               // reason the flag is deprecated, if any
+	deprecatedAliases map[string]string    // deprecated aliases, with reasons
+	occs              []Occurrence         // collected arguments, with their sources; guarded by mu
+	staged            *[]Occurrence        // if set, receives collected arguments during Reload; guarded by mu
+	prec              *Precedence          // if set, overrides DefaultPrecedence
+	convErrs          *ConversionErrors    // if set, overrides DefaultConversionErrors
+	val               string               // default value to display in help
+	defs              []string             // if set, default values; see ArgsOrDefault
+	isBool            bool                 // denotes if Value represent a boolean value
+	keep              keepMode             // the collected arguments returned by Args
+	warnIgnored       bool                 // denotes if arguments ignored under KeepFirst cause warnings
+	resets            []string             // arguments that discard those collected before them; see WithReset
+	assignReplaces    bool                 // denotes if arguments given with = replace those collected before them
+	countMax          int                  // limit of the count of a Bool, if positive; see WithMax
+	warnMax           bool                 // denotes if uses beyond countMax cause warnings
+	signed            bool                 // denotes if the count of a Bool may be negative; see Signed
+	toggle            bool                 // denotes if each use of a Bool flips its value; see Toggle
+	sep               string               // if set, each argument is split on sep into multiple values
+	envSep            rune                 // if set, the delimiter for environment variable values
+	stdin             bool                 // denotes if an argument of "-" is read from Stdin
+	stdinMode         Input                // how Stdin is read
+	files             bool                 // denotes if an argument that begins with @ names a file to read
+	fileMode          Input                // how files are read
+	paths             bool                 // denotes if arguments are split and cleaned as path lists
+	hint              ValueHint            // kind of values, for completion
+	complete          CompleteFunc         // if set, produces completion candidates at run time
+	choices           []string             // if set, the permitted values
+	pattern           *regexp.Regexp       // if set, the pattern permitted values match
+	raw               bool                 // denotes if arguments are collected without splitting or trimming
+	typ               string               // if set, the name of the type of the arguments; see Type
+	inner             flag.Value           // if set, receives each argument in effect before it is collected; see Wrap
+	check             func(string) error   // if set, validates each argument before it is collected
+	checkLoads        bool                 // denotes if check has effects, such as loading a file, so that checkDefaults skips it
+	validators        []func(string) error // validate each argument, after check
+	checkBatch        func([]string) error // if set, validates the arguments of a use together with those collected from the same source, after validators
+	constraints       []func() error       // checked by Validate
+	max               int                  // if set, the maximum number of values from a source
+	required          bool                 // denotes if the flag must have a value
+	noDups            bool                 // denotes if a repeated value from a source is an error
+	warnOnly          bool                 // denotes if failed constraints are warnings
+	bind              func()               // if set, updates a bound variable after the arguments change
+	after             func() error         // if set, called by Parse after the command line is parsed
+	isSet             bool                 // denotes if the flag was given on the command line
+	errs              *[]error             // if set, receives argument errors during Parse
+	assigned          []bool               // denotes, during Parse, if each remaining argument is given as -name=value
+	via               *Occurrence          // if set, the source of arguments given to Set; see CollectFrom
 }
 
 // String produces a string representation.
@@ -99,7 +163,168 @@ func (v *Value) String() string {
 // Set records a usage instance.
 // Provided for flag package.
 func (v *Value) Set(s string) error {
-	v.args = append(v.args, s)
+	return v.setAs(s, v.name)
+}
+
+// alias is registered for each alias of a Value, so that the name under which each argument is given is known.
+type alias struct {
+	*Value
+	name string
+}
+
+// Set records a usage instance under the alias.
+func (a *alias) Set(s string) error {
+	return a.setAs(s, a.name)
+}
+
+// String returns the value of the Value that a stands for.
+// The flag package calls it with a zero alias to determine whether a default value is shown.
+func (a *alias) String() string {
+	if a.Value == nil {
+		return ""
+	}
+	return a.Value.String()
+}
+
+// setAs records a usage instance given under name, which is the flag name, an alias or, for a Bool,
+// a negating flag, which records its effect on the count rather than s.
+func (v *Value) setAs(s string, name string) error {
+	assigned := false
+	if v.via == nil && len(v.assigned) > 0 {
+		assigned, v.assigned = v.assigned[0], v.assigned[1:]
+	}
+	arg, negated := s, contains(v.negations, name)
+	var err error
+	if negated {
+		var ok bool
+		if arg, ok, err = v.negate(s); err == nil && !ok {
+			return nil
+		}
+	}
+	switch {
+	case err != nil:
+	case v.via != nil:
+		return v.setFrom(arg, v.via.Source, v.via.Origin, DefaultDelimiter)
+	default:
+		err = v.set(arg, name, assigned && v.assignReplaces && !negated)
+	}
+	if err != nil && v.errs != nil {
+		*v.errs = append(*v.errs, &FlagError{Name: v.name, Used: name, Value: s, HasValue: true, Err: err})
+		if AggregateErrors {
+			return nil
+		}
+	}
+	return err
+}
+
+// set records a command line argument given under name,
+// replacing the arguments collected before it if replace is true.
+func (v *Value) set(s string, name string, replace bool) error {
+	args, err := v.expand(s)
+	if err != nil {
+		return err
+	}
+
+	if err := v.record(args, SourceFlag, name, replace); err != nil {
+		return err
+	}
+	v.isSet = true
+	if reason, ok := v.deprecatedAliases[name]; ok {
+		warnf(messages.DeprecatedFlag, name, deprecationText(reason))
+	} else if v.deprecated {
+		warnf(messages.DeprecatedFlag, name, deprecationText(v.deprecation))
+	}
+	return nil
+}
+
+// Append adds values to the collected arguments, as if each had been given on the command line,
+// but without splitting and without causing IsSet to return true.
+// It permits values from other sources to be accumulated with those from the command line.
+// If any value is invalid, none are added.
+func (v *Value) Append(values ...string) error {
+	return v.collect(values, SourceAppend, "")
+}
+
+// collect validates args and adds them to the collected arguments as coming from src and origin.
+func (v *Value) collect(args []string, src Source, origin string) error {
+	return v.record(args, src, origin, false)
+}
+
+// record is collect, but if replace is true, args replace the arguments collected before them
+// from the same or a lower ranked source.
+func (v *Value) record(args []string, src Source, origin string, replace bool) error {
+	for _, arg := range args {
+		if contains(v.resets, arg) {
+			continue
+		}
+		if err := v.validate(arg, true); err != nil {
+			return err
+		}
+	}
+	if err := v.add(args, src, origin, replace); err != nil {
+		return err
+	}
+	if v.bind != nil {
+		v.bind()
+	}
+	return nil
+}
+
+// add checks args against the arguments already collected, or staged by Reload, and adds them if they pass.
+// If any argument is rejected, nothing is changed.
+func (v *Value) add(args []string, src Source, origin string, replace bool) error {
+	mu.Lock()
+	defer mu.Unlock()
+	occs := &v.occs
+	if v.staged != nil {
+		occs = v.staged
+	}
+	kept := *occs
+	if replace {
+		kept = outranked(kept, src)
+	}
+	for i := len(args) - 1; i >= 0; i-- {
+		if contains(v.resets, args[i]) {
+			kept = outranked(kept, src)
+			args = args[i+1:]
+			break
+		}
+	}
+	if v.checkBatch != nil {
+		if err := v.checkBatch(append(sameSource(kept, src), args...)); err != nil {
+			return err
+		}
+	}
+	if err := v.relax("", v.checkLimits(kept, args, src)); err != nil {
+		return err
+	}
+	if v.inner != nil && !v.shadowed(kept, src) {
+		for _, arg := range args {
+			if err := v.inner.Set(arg); err != nil {
+				return err
+			}
+		}
+	}
+	if v.keep == keepFirst && v.warnIgnored {
+		same := sameSource(kept, src)
+		for i, arg := range args {
+			if len(same) > 0 || i > 0 {
+				warnf(messages.Ignored, v.name, arg)
+			}
+		}
+	}
+	prior := len(kept)
+	for _, arg := range args {
+		kept = append(kept, Occurrence{Value: arg, Source: src, Origin: origin})
+	}
+	if v.isBool && v.warnMax {
+		// Warn once, when the arguments from src first exceed the limit.
+		_, before := v.count(sameSource(kept[:prior], src))
+		if _, after := v.count(sameSource(kept, src)); after && !before {
+			warnf(messages.Capped, v.name, v.countMax)
+		}
+	}
+	*occs = kept
 	return nil
 }
 
@@ -107,20 +332,55 @@ func (v *Value) Set(s string) error {
 // Provided for flag package.
 func (v *Value) IsBoolFlag() bool { return v.isBool }
 
+// Type returns the name of the value type.
+// Provided for the github.com/spf13/pflag package, whose Value interface requires it.
+func (v *Value) Type() string {
+	switch {
+	case v.isBool:
+		return "bool"
+	case v.typ != "":
+		return v.typ
+	}
+	return "string"
+}
+
+// Flagger registers a flag.Value under a name. flag.Var and (*flag.FlagSet).Var are Flaggers.
 type Flagger func(val flag.Value, name string, usage string)
 
-func newString(fn Flagger, name string, value string, usage string, aliases ...string) *Value {
-	v := &Value{val: value}
+// RepeatableMarker is appended, after a space, to the usage text of each multiflag value when it is created,
+// so that help output shows that the flag may be repeated. It may be changed, or set to the empty string
+// to omit the marker, before values are created. SetLocale sets it from the selected message catalog.
+var RepeatableMarker = "(may be repeated)"
+
+// registrations counts the values registered, to record their order.
+var registrations int
+
+// register associates v with name, and each alias, using fn.
+func register(fn Flagger, v *Value, name string, usage string, aliases ...string) *Value {
+	registrations++
+	v.seq = registrations
+	v.flagger = fn
+	v.name = name
+	v.aliases = aliases
+	v.usage = usage
+
+	if RepeatableMarker != "" {
+		usage += " " + RepeatableMarker
+	}
 
 	fn(v, name, usage)
 
-	for _, alias := range aliases {
-		fn(v, alias, AliasUsage(name, alias))
+	for _, a := range aliases {
+		fn(&alias{Value: v, name: a}, a, AliasUsage(name, a))
 	}
 
 	return v
 }
 
+func newString(fn Flagger, name string, value string, usage string, aliases ...string) *Value {
+	return register(fn, &Value{val: value}, name, usage, aliases...)
+}
+
 // String returns a string multiflag instance associated with flag.
 // name, value, and usage are used to initial a flag.Value.
 // aliases, if any, initialize aliases for name. See AliasUsage.
@@ -133,9 +393,31 @@ func StringSet(flg *flag.FlagSet, name string, value string, usage string, alias
 	return newString(flg.Var, name, value, usage, aliases...)
 }
 
+func newStringWithDefaults(fn Flagger, name string, defaults []string, usage string, aliases ...string) *Value {
+	v := &Value{val: strings.Join(defaults, ","), defs: append([]string{}, defaults...)}
+	return register(fn, v, name, usage, aliases...)
+}
+
+// StringWithDefaults returns a string multiflag instance, associated with flag, that has a list of default values.
+// The defaults are returned by ArgsOrDefault if the flag is not used, and are shown, comma separated, in help.
+func StringWithDefaults(name string, defaults []string, usage string, aliases ...string) *Value {
+	return newStringWithDefaults(flag.Var, name, defaults, usage, aliases...)
+}
+
+// StringWithDefaultsSet creates a StringWithDefaults multiflag instance, associates it with the provided FlagSet and returns it.
+func StringWithDefaultsSet(flg *flag.FlagSet, name string, defaults []string, usage string, aliases ...string) *Value {
+	return newStringWithDefaults(flg.Var, name, defaults, usage, aliases...)
+}
+
+// StringWith creates a string multiflag instance and registers it, and its aliases, with fn.
+// It supports flag parsers other than the flag package.
+func StringWith(fn Flagger, name string, value string, usage string, aliases ...string) *Value {
+	return newString(fn, name, value, usage, aliases...)
+}
+
 func newBool(fn Flagger, name string, value string, usage string, aliases ...string) *Value {
-	v := newString(fn, name, value, usage, aliases...)
-	v.isBool = true
+	v := register(fn, &Value{val: value, isBool: true}, name, usage, aliases...)
+	v.checkDefaults()
 	return v
 }
 
@@ -151,19 +433,132 @@ func BoolSet(flg *flag.FlagSet, name string, value string, usage string, aliases
 	return newBool(flg.Var, name, value, usage, aliases...)
 }
 
+// BoolWith creates a boolean multiflag instance and registers it, and its aliases, with fn.
+// It supports flag parsers other than the flag package.
+func BoolWith(fn Flagger, name string, value string, usage string, aliases ...string) *Value {
+	return newBool(fn, name, value, usage, aliases...)
+}
+
+func newFunc(fn Flagger, name string, usage string, f func(string) error, aliases ...string) *Value {
+	v := newString(fn, name, "", usage, aliases...)
+	v.check = f
+	return v
+}
+
+// Func returns a string multiflag instance associated with flag.
+// f is called with each argument as it is parsed; an error from f rejects the argument.
+// Accepted arguments are collected as with String.
+func Func(name string, usage string, f func(string) error, aliases ...string) *Value {
+	return newFunc(flag.Var, name, usage, f, aliases...)
+}
+
+// FuncSet creates a Func multiflag instance, associates it with the provided FlagSet and returns it.
+func FuncSet(flg *flag.FlagSet, name string, usage string, f func(string) error, aliases ...string) *Value {
+	return newFunc(flg.Var, name, usage, f, aliases...)
+}
+
 // Args returns an array of collected arguments.
+// The array is a copy and may be modified by the caller.
 // A Bool always returns an empty array.
+// A value configured with KeepLast or KeepFirst returns at most one argument.
 func (v *Value) Args() []string {
 	if v.isBool {
 		return []string{}
 	} else {
-		return v.args
+		return v.kept(v.collected())
 	}
 }
 
-// NArg returns the number of invocations
+// Unique returns the collected arguments without duplicates, in the order they were first given.
+// A Bool always returns an empty array.
+func (v *Value) Unique() []string {
+	seen := make(map[string]bool)
+	out := []string{}
+	for _, arg := range v.Args() {
+		if !seen[arg] {
+			seen[arg] = true
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// ArgsOrDefault returns the collected arguments or, if there are none, the default values.
+// The default values are those given to StringWithDefaults or, otherwise, the default value.
+// An empty default value produces an empty array, as does a Bool.
+func (v *Value) ArgsOrDefault() []string {
+	if v.isBool {
+		return []string{}
+	}
+	if len(v.effective()) == 0 {
+		return v.defaults()
+	}
+	return v.Args()
+}
+
+// defaults returns the default values of v, as described for ArgsOrDefault.
+func (v *Value) defaults() []string {
+	if v.defs != nil {
+		return append([]string{}, v.defs...)
+	}
+	if v.val != "" {
+		return []string{v.val}
+	}
+	return []string{}
+}
+
+// Reset discards the collected arguments, returning v to its state before parsing.
+// It permits v to be reused when a FlagSet is parsed more than once.
+func (v *Value) Reset() {
+	mu.Lock()
+	v.occs = nil
+	mu.Unlock()
+	v.isSet = false
+	if v.bind != nil {
+		v.bind()
+	}
+}
+
+// IsSet returns a value denoting whether the flag was given on the command line.
+func (v *Value) IsSet() bool {
+	return v.isSet
+}
+
+// NArg returns the number of invocations.
+// For a Bool, an invocation with a false value, such as -verbose=false, resets the count to zero,
+// one with an unsigned integer, such as -verbose=2, sets it, and -verbose=0 resets it,
+// and one with a signed integer, such as -verbose=-1, adjusts it, but not below zero.
+// The count of a Bool is limited by WithMax and, if it is Signed, is that given by Count, or zero if that is negative.
 func (v *Value) NArg() int {
-	return len(v.args)
+	args := v.collected()
+	if !v.isBool {
+		return len(args)
+	}
+	n, _ := v.count(args)
+	if n < 0 {
+		n = 0
+	}
+	return n
+}
+
+// BoolValue returns the boolean value of the last invocation or,
+// if there are none, of the default value. For a Bool, whose invocations may set a count,
+// the value of the invocations is whether the count, given by NArg, is positive or, if it is a Toggle,
+// the default value flipped by each invocation.
+// It is intended for a Bool, but is defined for any value whose arguments are parsed by strconv.ParseBool.
+func (v *Value) BoolValue() bool {
+	s := v.val
+	if args := v.collected(); len(args) > 0 {
+		if v.toggle {
+			return v.toggled(args)
+		}
+		if v.isBool {
+			return v.NArg() > 0
+		}
+		s = args[len(args)-1]
+	}
+	b, _ := strconv.ParseBool(s)
+	return b
 }
 
 // AliasUsageFunc specifies the signature for an alias usage function.
@@ -171,6 +566,7 @@ type AliasUsageFunc func(orig, alias string) string
 
 // AliasUsage returns the usage text for an alias.
 // The function is a variable that may be changed to point to a custom function of type AliasUsageFunc.
+// By default, it formats the Alias message of the catalog selected by SetLocale.
 var AliasUsage AliasUsageFunc = func(orig, alias string) string {
-	return "Alias for " + orig
+	return fmt.Sprintf(messages.Alias, orig)
 }
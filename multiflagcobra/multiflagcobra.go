@@ -0,0 +1,59 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package multiflagcobra attaches multiflag values to github.com/spf13/cobra commands,
+so that Cobra programs count and collect repeated flags, and complete their values.
+
+	var verbosity = multiflagcobra.Bool(cmd, "verbose", "v", "", "Verbosity. Repeat as necessary")
+	var trace = multiflagcobra.String(cmd, "trace", "t", "none", "Trace program sections")
+	trace.WithChoices("none", "parse", "compile")
+*/
+package multiflagcobra
+
+import (
+	"github.com/gyepisam/multiflag"
+	"github.com/gyepisam/multiflag/multiflagpflag"
+	"github.com/spf13/cobra"
+)
+
+// Attach registers v with the flags of cmd under name, with the one letter shorthand, if it is not empty,
+// and usage, and arranges for Cobra to complete its values as for multiflag.HandleCompletion:
+// by the candidates of v or, for file and directory values, by the shell.
+// It permits a value created elsewhere, such as for a FlagSet shared by several programs, to be used by cmd.
+func Attach(cmd *cobra.Command, v *multiflag.Value, name, shorthand, usage string) {
+	multiflagpflag.FlaggerP(cmd.Flags(), shorthand)(v, name, usage)
+	complete(cmd, v, name)
+}
+
+// complete registers a Cobra completion function, for the flag name of cmd, that completes the values of v.
+func complete(cmd *cobra.Command, v *multiflag.Value, name string) {
+	if v.IsBoolFlag() {
+		return
+	}
+	// An error denotes that a completion function is already registered, which is left in place.
+	_ = cmd.RegisterFlagCompletionFunc(name, func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch v.Hint() {
+		case multiflag.FileValue:
+			return nil, cobra.ShellCompDirectiveDefault
+		case multiflag.DirValue:
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		}
+		return v.Candidates(toComplete), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// String creates a string multiflag instance, attaches it to cmd and returns it.
+// Completion is wired as for Attach, so later restrictions, such as WithChoices, are offered.
+func String(cmd *cobra.Command, name string, shorthand string, value string, usage string) *multiflag.Value {
+	v := multiflagpflag.StringP(cmd.Flags(), name, shorthand, value, usage)
+	complete(cmd, v, name)
+	return v
+}
+
+// Bool creates a boolean multiflag instance, attaches it to cmd and returns it.
+// Combined uses of the shorthand, such as -vvv, are counted.
+func Bool(cmd *cobra.Command, name string, shorthand string, value string, usage string) *multiflag.Value {
+	return multiflagpflag.BoolP(cmd.Flags(), name, shorthand, value, usage)
+}
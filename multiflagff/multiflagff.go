@@ -0,0 +1,56 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package multiflagff parses a FlagSet that contains multiflag values with github.com/peterbourgon/ff/v3,
+which also fills flags from environment variables and configuration files.
+
+ff sets flags from those sources through their Set methods, as if they had been given on the command line,
+and skips only the flags given there by name, so that a configuration key naming an alias,
+or a list in the environment, adds to the values given on the command line. Parse instead records
+such values with their source, so that Precedence applies.
+
+	fs := flag.NewFlagSet("main", flag.ContinueOnError)
+	var tags = multiflag.StringSet(fs, "tag", "", "Tags to apply", "t")
+	err := multiflagff.Parse(fs, os.Args[1:],
+		ff.WithEnvVarPrefix("MAIN"),
+		ff.WithEnvVarSplit(","),
+		ff.WithConfigFileFlag("config"),
+		ff.WithConfigFileParser(ff.PlainParser),
+	)
+*/
+package multiflagff
+
+import (
+	"flag"
+
+	"github.com/gyepisam/multiflag"
+	"github.com/peterbourgon/ff/v3"
+)
+
+// Parse parses the command line args with multiflag.Parse and then calls ff.Parse with options
+// to fill the flags in fs from the environment and configuration files. Multiflag values record
+// the arguments from the environment as coming from multiflag.SourceEnv, and those from a configuration file
+// as coming from multiflag.SourceConfig; ff does not report the variable or file name, so Origin is empty.
+// The remaining arguments are available from fs.Args, as after flag.Parse.
+func Parse(fs *flag.FlagSet, args []string, options ...ff.Option) error {
+	if err := multiflag.Parse(fs, args); err != nil {
+		return err
+	}
+	rest := append([]string{"--"}, fs.Args()...)
+
+	// ff reads the environment before the configuration file and skips, in both, the flags already set.
+	// The first call therefore reads only the environment, with the configuration file disabled,
+	// and the second only the configuration file, since the flags the environment sets are then skipped.
+	envOnly := append(options[:len(options):len(options)], ff.WithConfigFileVia(new(string)), ff.WithConfigFileFlag(""))
+	err := multiflag.CollectFrom(fs, multiflag.SourceEnv, "", func() error {
+		return ff.Parse(fs, rest, envOnly...)
+	})
+	if err != nil {
+		return err
+	}
+	return multiflag.CollectFrom(fs, multiflag.SourceConfig, "", func() error {
+		return ff.Parse(fs, rest, options...)
+	})
+}
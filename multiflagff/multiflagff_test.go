@@ -0,0 +1,87 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflagff_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gyepisam/multiflag"
+	"github.com/gyepisam/multiflag/multiflagff"
+	"github.com/peterbourgon/ff/v3"
+)
+
+func TestParseSources(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "app.conf")
+	if err := os.WriteFile(config, []byte("tag config\nt alias\nlevel high\nname config\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_LEVEL", "low,medium")
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	tags := multiflag.StringSet(fs, "tag", "", "tags to apply", "t")
+	level := multiflag.StringSet(fs, "level", "", "levels to log")
+	name := multiflag.StringSet(fs, "name", "", "name to use")
+	err := multiflagff.Parse(fs, []string{"-t", "cli", "extra"},
+		ff.WithEnvVarPrefix("APP"),
+		ff.WithEnvVarSplit(","),
+		ff.WithConfigFileVia(&config),
+		ff.WithConfigFileParser(ff.PlainParser),
+	)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		v    *multiflag.Value
+		want []multiflag.Occurrence
+	}{
+		{"tag", tags, []multiflag.Occurrence{{Value: "cli", Source: multiflag.SourceFlag, Origin: "t"}}},
+		{"level", level, []multiflag.Occurrence{{Value: "low", Source: multiflag.SourceEnv}, {Value: "medium", Source: multiflag.SourceEnv}}},
+		{"name", name, []multiflag.Occurrence{{Value: "config", Source: multiflag.SourceConfig}}},
+	} {
+		if got := tc.v.Occurrences(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("-%s: got %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+	if got := fs.Args(); !reflect.DeepEqual(got, []string{"extra"}) {
+		t.Errorf("Args: got %q, want [extra]", got)
+	}
+}
+
+func ExampleParse() {
+	os.Setenv("EXAMPLE_TAG", "red,green")
+	os.Setenv("EXAMPLE_LABEL", "env")
+	defer os.Unsetenv("EXAMPLE_TAG")
+	defer os.Unsetenv("EXAMPLE_LABEL")
+
+	fs := flag.NewFlagSet("example", flag.ContinueOnError)
+	tags := multiflag.StringSet(fs, "tag", "", "tags to apply", "t")
+	labels := multiflag.StringSet(fs, "label", "", "labels to apply", "l")
+
+	err := multiflagff.Parse(fs, []string{"-l", "cli"},
+		ff.WithEnvVarPrefix("EXAMPLE"),
+		ff.WithEnvVarSplit(","),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, o := range tags.Occurrences() {
+		fmt.Printf("-tag %s (%s)\n", o.Value, o.Source)
+	}
+	for _, o := range labels.Occurrences() {
+		fmt.Printf("-label %s (%s)\n", o.Value, o.Source)
+	}
+	// Output:
+	// -tag red (environment)
+	// -tag green (environment)
+	// -label cli (command line)
+}
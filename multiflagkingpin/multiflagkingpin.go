@@ -0,0 +1,73 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package multiflagkingpin registers multiflag values with a github.com/alecthomas/kingpin/v2
+application or command, for programs that use kingpin or are migrating to or from it.
+
+	app := kingpin.New("main", "An example")
+	var verbosity = multiflagkingpin.Bool(app, "verbose", "false", "Verbosity. Repeat as necessary", "v")
+	var trace = multiflagkingpin.String(app, "trace", "none", "Trace program sections", "t")
+*/
+package multiflagkingpin
+
+import (
+	"flag"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/gyepisam/multiflag"
+)
+
+// FlagGroup is implemented by a kingpin Application and CmdClause.
+type FlagGroup interface {
+	Flag(name, help string) *kingpin.FlagClause
+}
+
+// value adapts a multiflag value to kingpin, which permits a flag to be repeated only if its value is cumulative.
+type value struct {
+	flag.Value
+}
+
+// IsCumulative reports that the flag may be repeated.
+func (v value) IsCumulative() bool { return true }
+
+// IsBoolFlag reports whether the flag takes no argument.
+func (v value) IsBoolFlag() bool {
+	b, ok := v.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// Flagger returns a multiflag.Flagger that registers values with g.
+// A one letter alias becomes the kingpin short flag, as in -v, of the flag registered before it
+// for the same value, rather than a separate flag. The values of a flag that takes an argument
+// are completed by kingpin from its multiflag candidates.
+func Flagger(g FlagGroup) multiflag.Flagger {
+	clauses := make(map[*multiflag.Value]*kingpin.FlagClause)
+	return func(val flag.Value, name string, usage string) {
+		mv, ok := multiflag.ValueOf(val)
+		if clause, found := clauses[mv]; ok && found && len([]rune(name)) == 1 {
+			clause.Short([]rune(name)[0])
+			return
+		}
+		v := value{val}
+		clause := g.Flag(name, usage)
+		clause.SetValue(v)
+		if ok && !v.IsBoolFlag() {
+			clause.HintAction(func() []string { return mv.Candidates("") })
+		}
+		if ok {
+			clauses[mv] = clause
+		}
+	}
+}
+
+// String creates a string multiflag instance, associates it with the provided application or command and returns it.
+func String(g FlagGroup, name string, value string, usage string, aliases ...string) *multiflag.Value {
+	return multiflag.StringWith(Flagger(g), name, value, usage, aliases...)
+}
+
+// Bool creates a boolean multiflag instance, associates it with the provided application or command and returns it.
+func Bool(g FlagGroup, name string, value string, usage string, aliases ...string) *multiflag.Value {
+	return multiflag.BoolWith(Flagger(g), name, value, usage, aliases...)
+}
@@ -0,0 +1,65 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package multiflagpflag registers multiflag values with a github.com/spf13/pflag FlagSet,
+for use in pflag and Cobra based programs.
+
+	fs := pflag.NewFlagSet("main", pflag.ContinueOnError)
+	var verbosity = multiflagpflag.Bool(fs, "verbose", "false", "Verbosity. Repeat as necessary", "v")
+	var trace = multiflagpflag.String(fs, "trace", "none", "Trace program sections", "t")
+
+The P variants, such as BoolP, register a one letter pflag shorthand in place of an alias,
+so that combined uses, such as -vvv, are parsed by pflag and counted by multiflag.
+
+	var verbosity = multiflagpflag.BoolP(fs, "verbose", "v", "", "Verbosity. Repeat as necessary")
+*/
+package multiflagpflag
+
+import (
+	"flag"
+
+	"github.com/gyepisam/multiflag"
+	"github.com/spf13/pflag"
+)
+
+// Flagger returns a multiflag.Flagger that registers values with fs.
+// Boolean values are registered so that they do not require an argument.
+func Flagger(fs *pflag.FlagSet) multiflag.Flagger {
+	return FlaggerP(fs, "")
+}
+
+// FlaggerP is Flagger, but gives the first flag it registers, which is the name of a value,
+// the one letter shorthand, if it is not empty.
+func FlaggerP(fs *pflag.FlagSet, shorthand string) multiflag.Flagger {
+	return func(val flag.Value, name string, usage string) {
+		v := val.(pflag.Value)
+		fs.VarP(v, name, shorthand, usage)
+		shorthand = ""
+		if b, ok := val.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			fs.Lookup(name).NoOptDefVal = "true"
+		}
+	}
+}
+
+// String creates a string multiflag instance, associates it with the provided FlagSet and returns it.
+func String(fs *pflag.FlagSet, name string, value string, usage string, aliases ...string) *multiflag.Value {
+	return multiflag.StringWith(Flagger(fs), name, value, usage, aliases...)
+}
+
+// Bool creates a boolean multiflag instance, associates it with the provided FlagSet and returns it.
+func Bool(fs *pflag.FlagSet, name string, value string, usage string, aliases ...string) *multiflag.Value {
+	return multiflag.BoolWith(Flagger(fs), name, value, usage, aliases...)
+}
+
+// StringP is String, but registers the value with a true pflag shorthand, as in -t, rather than an alias.
+func StringP(fs *pflag.FlagSet, name string, shorthand string, value string, usage string, aliases ...string) *multiflag.Value {
+	return multiflag.StringWith(FlaggerP(fs, shorthand), name, value, usage, aliases...)
+}
+
+// BoolP is Bool, but registers the value with a true pflag shorthand, so that POSIX style
+// combined uses, such as -vvv, are counted.
+func BoolP(fs *pflag.FlagSet, name string, shorthand string, value string, usage string, aliases ...string) *multiflag.Value {
+	return multiflag.BoolWith(FlaggerP(fs, shorthand), name, value, usage, aliases...)
+}
@@ -0,0 +1,89 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package multiflagviper makes the multiflag values in a FlagSet available through github.com/spf13/viper,
+so that a Viper based program can adopt multiflag gradually.
+
+	fs := flag.NewFlagSet("main", flag.ContinueOnError)
+	multiflag.StringSet(fs, "tag", "", "Tags to apply", "t")
+	multiflag.BoolSet(fs, "verbose", "", "Verbosity. Repeat as necessary", "v")
+	multiflagviper.Bind(viper.GetViper(), fs)
+	...
+	tags := viper.GetStringSlice("tag")
+	verbosity := viper.GetInt("verbose")
+*/
+package multiflagviper
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/gyepisam/multiflag"
+	"github.com/spf13/viper"
+)
+
+// value presents a multiflag value to Viper as a flag.
+type value struct {
+	name string
+	v    *multiflag.Value
+}
+
+// HasChanged reports whether the value has arguments from the command line, or from Append,
+// which Viper ranks above its environment, configuration and defaults.
+func (fv value) HasChanged() bool {
+	for _, o := range fv.v.Occurrences() {
+		if o.Source == multiflag.SourceFlag || o.Source == multiflag.SourceAppend {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the flag name.
+func (fv value) Name() string { return fv.name }
+
+// ValueString returns the count of a Bool or, for other values, the arguments or defaults,
+// in the bracketed, comma separated form that Viper expects of a string slice.
+func (fv value) ValueString() string {
+	if fv.v.IsBoolFlag() {
+		return strconv.Itoa(fv.v.NArg())
+	}
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write(fv.v.ArgsOrDefault())
+	w.Flush()
+	return "[" + strings.TrimSuffix(b.String(), "\n") + "]"
+}
+
+// ValueType returns the Viper type of the value: int for the count of a Bool and stringSlice otherwise.
+func (fv value) ValueType() string {
+	if fv.v.IsBoolFlag() {
+		return "int"
+	}
+	return "stringSlice"
+}
+
+// Bind binds each multiflag value in fs to the Viper key of its name, and registers its aliases
+// as Viper aliases of that key. A Bool is seen by Viper as an int, its count, and other values
+// as string slices. Values given on the command line take precedence, in Viper, over its environment,
+// configuration and defaults; otherwise, the defaults of the multiflag value rank lowest.
+func Bind(vp *viper.Viper, fs *flag.FlagSet) error {
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		v, name, ok := multiflag.Lookup(fs, f.Name)
+		if !ok || err != nil {
+			return
+		}
+		if f.Name != name {
+			vp.RegisterAlias(f.Name, name)
+			return
+		}
+		err = vp.BindFlagValue(name, value{name: name, v: v})
+	})
+	return err
+}
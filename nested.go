@@ -0,0 +1,101 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NestedValue collects repeated key=value flag arguments, whose keys are dotted paths, into nested maps.
+// For example, "-set server.port=8080 -set server.tls.enabled=true" produces
+//
+//	map[string]interface{}{"server": map[string]interface{}{"port": int64(8080), "tls": map[string]interface{}{"enabled": true}}}
+type NestedValue struct {
+	*Value
+}
+
+// nestedScalar converts a value to a bool or int64 if possible, and otherwise leaves it as a string.
+func nestedScalar(s string) interface{} {
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	return s
+}
+
+// buildNested assembles args into nested maps. A later value replaces an earlier one for the same key,
+// but a key may not be both a value and a map.
+func buildNested(args []string) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	for _, arg := range args {
+		key, value, err := splitKeyValue(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		parts := strings.Split(key, ".")
+		for _, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf("%q has an empty key element", key)
+			}
+		}
+
+		m := root
+		for i, part := range parts[:len(parts)-1] {
+			switch child := m[part].(type) {
+			case nil:
+				next := make(map[string]interface{})
+				m[part] = next
+				m = next
+			case map[string]interface{}:
+				m = child
+			default:
+				return nil, fmt.Errorf("key %q conflicts with value for %q", key, strings.Join(parts[:i+1], "."))
+			}
+		}
+
+		last := parts[len(parts)-1]
+		if _, ok := m[last].(map[string]interface{}); ok {
+			return nil, fmt.Errorf("key %q conflicts with keys below it", key)
+		}
+		m[last] = nestedScalar(value)
+	}
+	return root, nil
+}
+
+func newNested(fn Flagger, name string, value string, usage string, aliases ...string) *NestedValue {
+	n := &NestedValue{Value: newString(fn, name, value, usage, aliases...)}
+	n.checkBatch = func(args []string) error {
+		_, err := buildNested(args)
+		return err
+	}
+	n.checkDefaults()
+	return n
+}
+
+// Nested returns a nested map multiflag instance associated with flag.
+// Each argument must be of the form key=value, where key is a dot separated path.
+// Values of true and false become bools, integers become int64s and others remain strings.
+// An argument whose key conflicts with one given before it from the same source, as a.b=1 does with a=1,
+// is rejected; arguments from other sources are combined according to Precedence, and may conflict only in Map.
+func Nested(name string, value string, usage string, aliases ...string) *NestedValue {
+	return newNested(flag.Var, name, value, usage, aliases...)
+}
+
+// NestedSet creates a Nested multiflag instance, associates it with the provided FlagSet and returns it.
+func NestedSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *NestedValue {
+	return newNested(flg.Var, name, value, usage, aliases...)
+}
+
+// Map returns the collected values as nested maps, or an error if they conflict,
+// as values from different sources may.
+func (n *NestedValue) Map() (map[string]interface{}, error) {
+	return buildNested(n.Args())
+}
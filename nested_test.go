@@ -0,0 +1,65 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNested(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		prec Precedence
+		env  map[string]string
+		args []string
+		want map[string]interface{}
+		err  string
+	}{
+		{
+			name: "paths",
+			args: []string{"-set", "server.port=8080", "-set", "server.tls=true", "-set", "name=x"},
+			want: map[string]interface{}{"server": map[string]interface{}{"port": int64(8080), "tls": true}, "name": "x"},
+		},
+		{name: "value then map", args: []string{"-set", "a=1", "-set", "a.b=1"}, err: `key "a.b" conflicts with value for "a"`},
+		{name: "map then value", args: []string{"-set", "a.b=1", "-set", "a=1"}, err: `key "a" conflicts with keys below it`},
+		{name: "empty element", args: []string{"-set", "a..b=1"}, err: "empty key element"},
+		{
+			name: "environment outranked",
+			env:  map[string]string{"APP_SET": "a=1"},
+			args: []string{"-set", "a.b=1"},
+			want: map[string]interface{}{"a": map[string]interface{}{"b": int64(1)}},
+		},
+		{name: "environment combined", prec: FlagsAppend, env: map[string]string{"APP_SET": "a=1"}, args: []string{"-set", "a.b=1"}, err: "conflicts"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("nested", flag.ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+			n := NestedSet(fs, "set", "", "settings")
+			n.WithPrecedence(tc.prec)
+			err := bindEnv(fs, "APP", env(tc.env))
+			if err == nil {
+				err = Parse(fs, tc.args)
+			}
+			var got map[string]interface{}
+			if err == nil {
+				got, err = n.Map()
+			}
+			switch {
+			case tc.err != "":
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("got error %v, want %q", err, tc.err)
+				}
+			case err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case !reflect.DeepEqual(got, tc.want):
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
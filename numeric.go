@@ -0,0 +1,145 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// IntValue collects repeated integer flag arguments.
+type IntValue struct {
+	*Value
+}
+
+func newInt(fn Flagger, name string, value string, usage string, aliases ...string) *IntValue {
+	i := &IntValue{Value: newString(fn, name, value, usage, aliases...)}
+	i.typ = "int"
+	i.check = func(s string) error {
+		_, err := strconv.Atoi(s)
+		return err
+	}
+	i.checkDefaults()
+	return i
+}
+
+// Int returns an integer multiflag instance associated with flag. Each argument must be an integer.
+func Int(name string, value string, usage string, aliases ...string) *IntValue {
+	return newInt(flag.Var, name, value, usage, aliases...)
+}
+
+// IntSet creates an Int multiflag instance, associates it with the provided FlagSet and returns it.
+func IntSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *IntValue {
+	return newInt(flg.Var, name, value, usage, aliases...)
+}
+
+// Values returns the collected integers.
+func (i *IntValue) Values() []int {
+	values, _ := i.Ints()
+	return values
+}
+
+// WithRange restricts the arguments of i to integers from min to max, inclusive.
+// WithRange returns i to permit chaining.
+func (i *IntValue) WithRange(min, max int) *IntValue {
+	i.WithValidator(func(s string) error {
+		if n, err := strconv.Atoi(s); err == nil && (n < min || n > max) {
+			return fmt.Errorf(messages.OutOfRange, s, min, max)
+		}
+		return nil
+	})
+	return i
+}
+
+// FloatValue collects repeated floating point flag arguments.
+type FloatValue struct {
+	*Value
+}
+
+func newFloat(fn Flagger, name string, value string, usage string, aliases ...string) *FloatValue {
+	f := &FloatValue{Value: newString(fn, name, value, usage, aliases...)}
+	f.typ = "float64"
+	f.check = func(s string) error {
+		_, err := strconv.ParseFloat(s, 64)
+		return err
+	}
+	f.checkDefaults()
+	return f
+}
+
+// Float returns a floating point multiflag instance associated with flag. Each argument must be a number.
+func Float(name string, value string, usage string, aliases ...string) *FloatValue {
+	return newFloat(flag.Var, name, value, usage, aliases...)
+}
+
+// FloatSet creates a Float multiflag instance, associates it with the provided FlagSet and returns it.
+func FloatSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *FloatValue {
+	return newFloat(flg.Var, name, value, usage, aliases...)
+}
+
+// Values returns the collected numbers.
+func (f *FloatValue) Values() []float64 {
+	values, _ := f.Floats()
+	return values
+}
+
+// WithRange restricts the arguments of f to numbers from min to max, inclusive.
+// WithRange returns f to permit chaining.
+func (f *FloatValue) WithRange(min, max float64) *FloatValue {
+	f.WithValidator(func(s string) error {
+		if x, err := strconv.ParseFloat(s, 64); err == nil && (x < min || x > max) {
+			return fmt.Errorf(messages.OutOfRange, s, min, max)
+		}
+		return nil
+	})
+	return f
+}
+
+// DurationValue collects repeated time.Duration flag arguments.
+type DurationValue struct {
+	*Value
+}
+
+func newDuration(fn Flagger, name string, value string, usage string, aliases ...string) *DurationValue {
+	d := &DurationValue{Value: newString(fn, name, value, usage, aliases...)}
+	d.typ = "duration"
+	d.check = func(s string) error {
+		_, err := time.ParseDuration(s)
+		return err
+	}
+	d.checkDefaults()
+	return d
+}
+
+// Duration returns a duration multiflag instance associated with flag.
+// Each argument must be accepted by time.ParseDuration.
+func Duration(name string, value string, usage string, aliases ...string) *DurationValue {
+	return newDuration(flag.Var, name, value, usage, aliases...)
+}
+
+// DurationSet creates a Duration multiflag instance, associates it with the provided FlagSet and returns it.
+func DurationSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *DurationValue {
+	return newDuration(flg.Var, name, value, usage, aliases...)
+}
+
+// Values returns the collected durations.
+func (d *DurationValue) Values() []time.Duration {
+	values, _ := d.Durations()
+	return values
+}
+
+// WithRange restricts the arguments of d to durations from min to max, inclusive.
+// WithRange returns d to permit chaining.
+func (d *DurationValue) WithRange(min, max time.Duration) *DurationValue {
+	d.WithValidator(func(s string) error {
+		if x, err := time.ParseDuration(s); err == nil && (x < min || x > max) {
+			return fmt.Errorf(messages.OutOfRange, s, min, max)
+		}
+		return nil
+	})
+	return d
+}
@@ -0,0 +1,103 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// AggregateErrors causes Parse to continue past invalid arguments to multiflag values,
+// such as those rejected by a validator, and to report them all in one error, compatible with errors.Join,
+// so that they can be corrected together. Errors that stop the flag package, such as an undefined flag,
+// still end parsing. Otherwise, Parse stops at the first invalid argument, as the flag package does,
+// so that the arguments after it have no effect. See also Validate.
+var AggregateErrors bool
+
+// scan returns, for each multiflag value in fs, whether each of the arguments args give it, in order,
+// is in the -name=value form. It follows the syntax of the flag package and stops where the flag package
+// stops, or would fail.
+func scan(fs *flag.FlagSet, args []string) map[*Value][]bool {
+	assigned := make(map[*Value][]bool)
+	for len(args) > 0 {
+		s := args[0]
+		if len(s) < 2 || s[0] != '-' || s == "--" {
+			break
+		}
+		name := strings.TrimPrefix(s[1:], "-")
+		if name == "" || name[0] == '-' || name[0] == '=' {
+			break
+		}
+		args = args[1:]
+
+		name, _, hasValue := strings.Cut(name, "=")
+		f := fs.Lookup(name)
+		if f == nil {
+			break
+		}
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); !hasValue && !(ok && b.IsBoolFlag()) {
+			if len(args) == 0 {
+				break
+			}
+			args = args[1:]
+		}
+		if n, ok := f.Value.(*negation); ok {
+			assigned[n.v] = append(assigned[n.v], hasValue)
+		} else if v, ok := valueOf(f); ok {
+			assigned[v] = append(assigned[v], hasValue)
+		}
+	}
+	return assigned
+}
+
+// Parse parses args with fs and then completes the processing of the multiflag values in fs
+// that require it, such as loading the default file of a ConfigFile flag that was not given.
+// An invalid argument to a multiflag value is reported as a FlagError, which names the flag,
+// or alias, that was used.
+// Use flag.CommandLine and os.Args[1:] in place of flag.Parse.
+func Parse(fs *flag.FlagSet, args []string) error {
+	var errs []error
+	assigned := scan(fs, args)
+	visit(fs, func(v *Value) { v.errs, v.assigned = &errs, assigned[v] })
+	defer visit(fs, func(v *Value) { v.errs, v.assigned = nil, nil })
+
+	err := fs.Parse(args)
+	if err != nil {
+		if !AggregateErrors && len(errs) > 0 {
+			// fs.Parse stopped at, and reported, the invalid argument.
+			return errs[0]
+		}
+		return errors.Join(append(errs, err)...)
+	}
+	if len(errs) > 0 {
+		return failParse(fs, errors.Join(errs...))
+	}
+	visit(fs, func(v *Value) {
+		if err == nil && v.after != nil {
+			err = v.after()
+		}
+	})
+	return err
+}
+
+// failParse reports err, and then handles it, as fs.Parse does for its own errors.
+func failParse(fs *flag.FlagSet, err error) error {
+	fmt.Fprintln(fs.Output(), err)
+	if fs.Usage != nil {
+		fs.Usage()
+	} else {
+		Usage(fs)()
+	}
+	switch fs.ErrorHandling() {
+	case flag.ExitOnError:
+		os.Exit(2)
+	case flag.PanicOnError:
+		panic(err)
+	}
+	return err
+}
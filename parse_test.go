@@ -0,0 +1,99 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	s := StringSet(fs, "s", "", "strings", "str")
+	v := BoolSet(fs, "v", "", "verbosity").Negatable(false)
+	fs.Int("n", 0, "an ordinary flag")
+	fs.Bool("b", false, "an ordinary boolean flag")
+
+	for _, tc := range []struct {
+		args  []string
+		wantS []bool
+		wantV []bool
+	}{
+		{args: []string{"-s", "a", "-s=b", "--str=c", "--str", "d"}, wantS: []bool{false, true, true, false}},
+		{args: []string{"-v", "-v=2", "-no-v", "-no-v=true"}, wantV: []bool{false, true, false, true}},
+		{args: []string{"-n", "1", "-s", "a", "-b", "-s", "-v", "-n=2", "-s=x"}, wantS: []bool{false, false, true}},
+		{args: []string{"-s", "a", "operand", "-s", "b"}, wantS: []bool{false}},
+		{args: []string{"-s", "a", "--", "-s", "b"}, wantS: []bool{false}},
+		{args: []string{"-s", "a", "-", "-s", "b"}, wantS: []bool{false}},
+		{args: []string{"-s", "a", "-undefined", "-s", "b"}, wantS: []bool{false}},
+		{args: []string{"-s", "a", "---s", "b"}, wantS: []bool{false}},
+		{args: []string{"-s", "a", "-=x", "-s", "b"}, wantS: []bool{false}},
+		{args: []string{"-v", "-s"}, wantV: []bool{false}},
+	} {
+		got := scan(fs, tc.args)
+		if !reflect.DeepEqual(got[s], tc.wantS) || !reflect.DeepEqual(got[v], tc.wantV) {
+			t.Errorf("scan(%q): got -s %v, -v %v; want -s %v, -v %v", tc.args, got[s], got[v], tc.wantS, tc.wantV)
+		}
+	}
+}
+
+func TestAssignReplaces(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"-tag", "c"}, want: []string{"a", "b", "c"}},
+		{args: []string{"-tag=c"}, want: []string{"c"}},
+		{args: []string{"-tag", "c", "-tag=d", "-tag", "e"}, want: []string{"d", "e"}},
+		{args: []string{"-n", "1", "-tag", "c", "-t=d,e", "-n=2", "-tag", "f"}, want: []string{"d", "e", "f"}},
+	} {
+		fs := flag.NewFlagSet("assign", flag.ContinueOnError)
+		tags := StringSet(fs, "tag", "", "tags", "t").Split().AssignReplaces().WithPrecedence(FlagsAppend)
+		fs.Int("n", 0, "an ordinary flag")
+		if err := bindEnv(fs, "", env(map[string]string{"TAG": "a,b"})); err != nil {
+			t.Fatal(err)
+		}
+		if err := Parse(fs, tc.args); err != nil {
+			t.Fatalf("Parse(%q): %v", tc.args, err)
+		}
+		if got := tags.Args(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Parse(%q): got %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestParseStopsAtFirstError(t *testing.T) {
+	defer func(aggregate bool) { AggregateErrors = aggregate }(AggregateErrors)
+	for _, aggregate := range []bool{false, true} {
+		AggregateErrors = aggregate
+		fs := flag.NewFlagSet("errors", flag.ContinueOnError)
+		fs.SetOutput(new(bytes.Buffer))
+		n := IntSet(fs, "n", "", "number", "num")
+		s := StringSet(fs, "s", "", "strings")
+
+		err := Parse(fs, []string{"-num", "x", "-s", "a", "-n", "y"})
+		var fe *FlagError
+		if !errors.As(err, &fe) || fe.Used != "num" || fe.Value != "x" {
+			t.Errorf("aggregate %v: got %v, want an error for -num x", aggregate, err)
+		}
+		got := 1
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			got = len(joined.Unwrap())
+		}
+		wantErrs, wantS := 1, 0
+		if aggregate {
+			wantErrs, wantS = 2, 1
+		}
+		if got != wantErrs {
+			t.Errorf("aggregate %v: got %d errors, want %d: %v", aggregate, got, wantErrs, err)
+		}
+		if s.NArg() != wantS || n.NArg() != 0 {
+			t.Errorf("aggregate %v: got -s %q and -n %q", aggregate, s.Args(), n.Args())
+		}
+	}
+}
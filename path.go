@@ -0,0 +1,119 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"path/filepath"
+)
+
+// splitPathList splits s on os.PathListSeparator and cleans each element.
+// Empty elements are dropped.
+func splitPathList(s string) []string {
+	paths := []string{}
+	for _, p := range filepath.SplitList(s) {
+		if p != "" {
+			paths = append(paths, filepath.Clean(p))
+		}
+	}
+	return paths
+}
+
+// PathList causes each argument to be split, like the PATH environment variable,
+// on os.PathListSeparator into multiple values, each of which is cleaned with filepath.Clean.
+// Completion offers file names for its values.
+// PathList returns v to permit chaining.
+func (v *Value) PathList() *Value {
+	v.raw = false
+	v.paths = true
+	v.hint = FileValue
+	return v
+}
+
+// PathList returns a string multiflag instance, associated with flag, whose arguments are path lists.
+// See (*Value).PathList.
+func PathList(name string, value string, usage string, aliases ...string) *Value {
+	return newString(flag.Var, name, value, usage, aliases...).PathList()
+}
+
+// PathListSet creates a PathList multiflag instance, associates it with the provided FlagSet and returns it.
+func PathListSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *Value {
+	return newString(flg.Var, name, value, usage, aliases...).PathList()
+}
+
+// WithHint sets the kind of values v takes, so that completion offers suitable candidates.
+// WithHint returns v to permit chaining.
+func (v *Value) WithHint(hint ValueHint) *Value {
+	v.hint = hint
+	return v
+}
+
+// File returns a string multiflag instance, associated with flag, whose arguments are file names.
+// Completion offers file names for its values.
+func File(name string, value string, usage string, aliases ...string) *Value {
+	return newString(flag.Var, name, value, usage, aliases...).WithHint(FileValue)
+}
+
+// FileSet creates a File multiflag instance, associates it with the provided FlagSet and returns it.
+func FileSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *Value {
+	return newString(flg.Var, name, value, usage, aliases...).WithHint(FileValue)
+}
+
+// Dir returns a string multiflag instance, associated with flag, whose arguments are directory names.
+// Completion offers directory names for its values.
+func Dir(name string, value string, usage string, aliases ...string) *Value {
+	return newString(flag.Var, name, value, usage, aliases...).WithHint(DirValue)
+}
+
+// DirSet creates a Dir multiflag instance, associates it with the provided FlagSet and returns it.
+func DirSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *Value {
+	return newString(flg.Var, name, value, usage, aliases...).WithHint(DirValue)
+}
+
+// GlobValue collects repeated file name patterns, as accepted by filepath.Match.
+type GlobValue struct {
+	*Value
+}
+
+func newGlob(fn Flagger, name string, value string, usage string, aliases ...string) *GlobValue {
+	g := &GlobValue{Value: newString(fn, name, value, usage, aliases...).WithHint(FileValue)}
+	g.check = func(s string) error {
+		_, err := filepath.Match(s, "")
+		return err
+	}
+	g.checkDefaults()
+	return g
+}
+
+// Glob returns a pattern multiflag instance associated with flag. Each argument must be a valid pattern.
+// Completion offers file names for its values.
+func Glob(name string, value string, usage string, aliases ...string) *GlobValue {
+	return newGlob(flag.Var, name, value, usage, aliases...)
+}
+
+// GlobSet creates a Glob multiflag instance, associates it with the provided FlagSet and returns it.
+func GlobSet(flg *flag.FlagSet, name string, value string, usage string, aliases ...string) *GlobValue {
+	return newGlob(flg.Var, name, value, usage, aliases...)
+}
+
+// Matches returns the names of the files that match the collected patterns, or the default pattern
+// if none were given, without duplicates, in the order of the patterns and then lexicographically.
+func (g *GlobValue) Matches() ([]string, error) {
+	seen := make(map[string]bool)
+	matches := []string{}
+	for _, pattern := range g.ArgsOrDefault() {
+		names, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				matches = append(matches, name)
+			}
+		}
+	}
+	return matches, nil
+}
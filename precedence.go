@@ -0,0 +1,108 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+// Precedence determines how arguments from the command line are combined with those from other sources,
+// such as the environment. Sources rank, from highest to lowest: the command line, the environment,
+// configuration files.
+// Values added with Append rank with the command line. See Source.
+type Precedence int
+
+const (
+	FlagsReplace Precedence = iota // only arguments from the highest ranked source present are kept
+	FlagsAppend                    // arguments from higher ranked sources follow those from lower ranked sources
+	FlagsPrepend                   // arguments from higher ranked sources precede those from lower ranked sources
+)
+
+// DefaultPrecedence determines how arguments from different sources are combined
+// for values that have not been configured with WithPrecedence.
+// It is consulted whenever collected arguments are retrieved.
+var DefaultPrecedence = FlagsReplace
+
+// WithPrecedence determines how arguments from different sources are combined for v,
+// overriding DefaultPrecedence.
+// WithPrecedence returns v to permit chaining.
+func (v *Value) WithPrecedence(p Precedence) *Value {
+	v.prec = &p
+	return v
+}
+
+// precedence returns the Precedence of v.
+func (v *Value) precedence() Precedence {
+	if v.prec != nil {
+		return *v.prec
+	}
+	return DefaultPrecedence
+}
+
+// effective returns the collected occurrences, combined according to precedence.
+func (v *Value) effective() []Occurrence {
+	byRank := make(map[int][]Occurrence)
+	top := -1
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, o := range v.occs {
+		r := o.Source.rank()
+		byRank[r] = append(byRank[r], o)
+		if r > top {
+			top = r
+		}
+	}
+
+	occs := []Occurrence{}
+	switch v.precedence() {
+	case FlagsAppend:
+		for r := 0; r <= top; r++ {
+			occs = append(occs, byRank[r]...)
+		}
+	case FlagsPrepend:
+		for r := top; r >= 0; r-- {
+			occs = append(occs, byRank[r]...)
+		}
+	default:
+		occs = append(occs, byRank[top]...)
+	}
+	return occs
+}
+
+// shadowed reports whether arguments from src would have no effect, given those in occs,
+// because a higher ranked source is present and only the highest is kept.
+func (v *Value) shadowed(occs []Occurrence, src Source) bool {
+	if v.precedence() != FlagsReplace {
+		return false
+	}
+	for _, o := range occs {
+		if o.Source.rank() > src.rank() {
+			return true
+		}
+	}
+	return false
+}
+
+// collected returns the collected arguments, combined according to precedence.
+func (v *Value) collected() []string {
+	occs := v.effective()
+	args := make([]string, len(occs))
+	for i, o := range occs {
+		args[i] = o.Value
+	}
+	return args
+}
+
+// setFrom adds an argument from a source other than the command line to v.
+// origin describes the source, as for Occurrence.
+// The argument is split on sep unless v specifies its own delimiter.
+// A Bool accepts a count, such as 3, which, as for -verbose=3, sets the count, in addition to a boolean value.
+func (v *Value) setFrom(s string, src Source, origin string, sep rune) error {
+	if v.isBool {
+		return v.collect([]string{s}, src, origin)
+	}
+
+	args, err := v.splitWith(s, sep)
+	if err != nil {
+		return err
+	}
+	return v.collect(args, src, origin)
+}
@@ -0,0 +1,94 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestPrecedence(t *testing.T) {
+	config := map[string]interface{}{"tag": []interface{}{"c1", "c2"}}
+	vars := map[string]string{"TAG": "e"}
+	for _, tc := range []struct {
+		name   string
+		prec   Precedence
+		config bool
+		env    bool
+		args   []string
+		want   []string
+	}{
+		{name: "replace, all sources", config: true, env: true, args: []string{"-tag", "f"}, want: []string{"f"}},
+		{name: "replace, no command line", config: true, env: true, want: []string{"e"}},
+		{name: "replace, config only", config: true, want: []string{"c1", "c2"}},
+		{name: "append", prec: FlagsAppend, config: true, env: true, args: []string{"-tag", "f"}, want: []string{"c1", "c2", "e", "f"}},
+		{name: "prepend", prec: FlagsPrepend, config: true, env: true, args: []string{"-tag", "f"}, want: []string{"f", "e", "c1", "c2"}},
+		{name: "reset", prec: FlagsAppend, config: true, env: true, args: []string{"-tag", "f", "-tag", "none", "-tag", "g"}, want: []string{"g"}},
+		{name: "reset in a split argument", prec: FlagsAppend, config: true, args: []string{"-tag", "f,none,g"}, want: []string{"g"}},
+		{name: "none", want: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("precedence", flag.ContinueOnError)
+			tags := StringSet(fs, "tag", "", "tags").Split().WithPrecedence(tc.prec).WithReset("none")
+			if tc.config {
+				if err := ApplyConfig(fs, config); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.env {
+				if err := bindEnv(fs, "", env(vars)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := Parse(fs, tc.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := tags.Args(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOccurrencesRecordOrigin(t *testing.T) {
+	fs := flag.NewFlagSet("origin", flag.ContinueOnError)
+	tags := StringSet(fs, "tag", "", "tags", "t").WithPrecedence(FlagsAppend)
+	if err := ApplyConfigFrom(fs, "app.json", map[string]interface{}{"tag": "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bindEnv(fs, "APP", env(map[string]string{"APP_TAG": "e"})); err != nil {
+		t.Fatal(err)
+	}
+	if err := Parse(fs, []string{"-t", "f", "-tag", "g"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []Occurrence{
+		{Value: "c", Source: SourceConfig, Origin: "app.json"},
+		{Value: "e", Source: SourceEnv, Origin: "APP_TAG"},
+		{Value: "f", Source: SourceFlag, Origin: "t"},
+		{Value: "g", Source: SourceFlag, Origin: "tag"},
+	}
+	if got := tags.Occurrences(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestPrecedenceIndependentOfOrder(t *testing.T) {
+	fs := flag.NewFlagSet("order", flag.ContinueOnError)
+	tags := StringSet(fs, "tag", "", "tags")
+	if err := Parse(fs, []string{"-tag", "f"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bindEnv(fs, "", env(map[string]string{"TAG": "e"})); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyConfig(fs, map[string]interface{}{"tag": "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := tags.Args(); !reflect.DeepEqual(got, []string{"f"}) {
+		t.Errorf("got %q, want [f]", got)
+	}
+}
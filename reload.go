@@ -0,0 +1,59 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"sync"
+)
+
+// mu guards the collected arguments of all values, so that Reload can replace them atomically.
+var mu sync.RWMutex
+
+// Reload replaces the arguments that the multiflag values in fs have collected from configuration files
+// and the environment with those applied by load, which typically calls a loader such as LoadJSON and BindEnv.
+// Arguments from the command line and Append are retained. The arguments applied by load are checked,
+// as for RejectDuplicates or MaxOccurrences, against the retained arguments and each other,
+// not against those they replace.
+//
+// The replacement is atomic: other goroutines see either the old or the new arguments for all values,
+// and if load returns an error, no value is changed. Reload is intended for long running programs that
+// reread their configuration, for instance on receipt of SIGHUP.
+func Reload(fs *flag.FlagSet, load func(fs *flag.FlagSet) error) error {
+	var values []*Value
+	mu.Lock()
+	visit(fs, func(v *Value) {
+		staged := []Occurrence{}
+		for _, o := range v.occs {
+			if o.Source == SourceFlag || o.Source == SourceAppend {
+				staged = append(staged, o)
+			}
+		}
+		v.staged = &staged
+		values = append(values, v)
+	})
+	mu.Unlock()
+
+	err := load(fs)
+
+	mu.Lock()
+	for _, v := range values {
+		if err == nil {
+			v.occs = *v.staged
+		}
+		v.staged = nil
+	}
+	mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if v.bind != nil {
+			v.bind()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"testing"
+)
+
+// applying returns a load function, for Reload, that applies config.
+func applying(config map[string]interface{}) func(*flag.FlagSet) error {
+	return func(fs *flag.FlagSet) error {
+		return ApplyConfig(fs, config)
+	}
+}
+
+func TestReload(t *testing.T) {
+	fs := flag.NewFlagSet("reload", flag.ContinueOnError)
+	define := StringMapSet(fs, "define", "", "definitions", RejectDuplicates)
+	set := NestedSet(fs, "set", "", "settings")
+	tags := StringSet(fs, "tag", "", "tags").WithPrecedence(FlagsAppend)
+
+	if err := ApplyConfig(fs, map[string]interface{}{"define": []interface{}{"a=1"}, "set": []interface{}{"a=1"}, "tag": []interface{}{"config"}}); err != nil {
+		t.Fatalf("ApplyConfig: %v", err)
+	}
+	if err := Parse(fs, []string{"-tag", "cli"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	// The staged arguments, not those being replaced, are checked.
+	config := map[string]interface{}{"define": []interface{}{"a=1"}, "set": []interface{}{"a.b=1"}, "tag": []interface{}{"reloaded"}}
+	if err := Reload(fs, applying(config)); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got, want := define.Map(), map[string]string{"a": "1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("-define: got %v, want %v", got, want)
+	}
+	if got, err := set.Map(); err != nil || !reflect.DeepEqual(got, map[string]interface{}{"a": map[string]interface{}{"b": int64(1)}}) {
+		t.Errorf("-set: got %v, %v", got, err)
+	}
+	if got, want := tags.Args(), []string{"reloaded", "cli"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("-tag: got %q, want %q", got, want)
+	}
+
+	// A failed load changes nothing.
+	errLoad := errors.New("load failed")
+	err := Reload(fs, func(fs *flag.FlagSet) error {
+		if err := ApplyConfig(fs, map[string]interface{}{"tag": []interface{}{"partial"}}); err != nil {
+			return err
+		}
+		return errLoad
+	})
+	if !errors.Is(err, errLoad) {
+		t.Errorf("Reload: got %v, want %v", err, errLoad)
+	}
+	if got, want := tags.Args(), []string{"reloaded", "cli"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("-tag after failed Reload: got %q, want %q", got, want)
+	}
+}
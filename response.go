@@ -0,0 +1,60 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"fmt"
+	"os"
+)
+
+// maxResponseDepth limits the nesting of response files, which guards against cycles.
+const maxResponseDepth = 10
+
+// ExpandResponseFiles returns args with each argument of the form @file replaced by the arguments
+// contained in file. Arguments in a file are separated by white space, including newlines, and may be
+// quoted or escaped as described for WithDelimiter. Response files may refer to other response files.
+// An argument consisting only of @, and arguments following --, are not expanded.
+//
+// A typical use is
+//
+//	args, err := multiflag.ExpandResponseFiles(os.Args[1:])
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	flag.CommandLine.Parse(args)
+func ExpandResponseFiles(args []string) ([]string, error) {
+	return expandResponseFiles(args, 0)
+}
+
+func expandResponseFiles(args []string, depth int) ([]string, error) {
+	out := make([]string, 0, len(args))
+	for i, arg := range args {
+		if arg == "--" {
+			return append(out, args[i:]...), nil
+		}
+		if len(arg) < 2 || arg[0] != '@' {
+			out = append(out, arg)
+			continue
+		}
+
+		if depth >= maxResponseDepth {
+			return nil, fmt.Errorf("response file %s: nested too deeply", arg[1:])
+		}
+		data, err := os.ReadFile(arg[1:])
+		if err != nil {
+			return nil, err
+		}
+		more, err := splitQuoted(string(data), ' ')
+		if err != nil {
+			return nil, fmt.Errorf("response file %s: %v", arg[1:], err)
+		}
+		more, err = expandResponseFiles(more, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, more...)
+	}
+	return out, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Format denotes a configuration file format.
+type Format int
+
+const (
+	JSON Format = iota // a JSON object, as read by LoadJSON
+	INI                // key = value lines, as read by LoadINI
+)
+
+// ConfigValues returns the current values of the flags in fs, in the form accepted by ApplyConfig.
+// A multiflag value with collected arguments appears under its name, but not its aliases, as an array
+// of arguments or, for a Bool, as its count. Arguments of a value that splits them, as with Split,
+// have their delimiters escaped, so that they are not split again when loaded.
+// ConfigFile values are omitted, since loading a file that names itself would not end.
+// Other flags appear if their values differ from their defaults.
+func ConfigValues(fs *flag.FlagSet) map[string]interface{} {
+	values := make(map[string]interface{})
+	fs.VisitAll(func(f *flag.Flag) {
+		v, ok := valueOf(f)
+		switch {
+		case !ok:
+			if s := f.Value.String(); s != f.DefValue {
+				values[f.Name] = s
+			}
+		case f.Name != v.name:
+			// an alias
+		case v.checkLoads:
+			// a ConfigFile, which would load the saved file again
+		case v.isBool:
+			if n := v.NArg(); n > 0 {
+				values[f.Name] = n
+			}
+		default:
+			args := v.Args()
+			if sep, ok := v.delimiter(DefaultDelimiter); ok {
+				for i, arg := range args {
+					args[i] = escapeQuoted(arg, sep)
+				}
+			}
+			if len(args) > 0 {
+				values[f.Name] = args
+			}
+		}
+	})
+	return values
+}
+
+// SaveConfig writes the current values of the flags in fs, as described for ConfigValues, to w
+// in the given format. The output may be loaded with the corresponding loader.
+func SaveConfig(fs *flag.FlagSet, w io.Writer, format Format) error {
+	values := ConfigValues(fs)
+	switch format {
+	case JSON:
+		data, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case INI:
+		return writeINI(w, values)
+	}
+	return fmt.Errorf("unknown format %d", int(format))
+}
+
+// writeINI writes values as key = value lines, repeating the key for each element of an array.
+func writeINI(w io.Writer, values map[string]interface{}) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var list []string
+		switch x := values[name].(type) {
+		case []string:
+			list = x
+		default:
+			list = []string{fmt.Sprint(x)}
+		}
+		for _, s := range list {
+			if strings.TrimSpace(s) != s || strings.HasPrefix(s, `"`) {
+				s = `"` + s + `"`
+			}
+			if _, err := fmt.Fprintf(w, "%s = %s\n", name, s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestSaveConfigRoundTrip(t *testing.T) {
+	args := []string{"-s", `a\,b,c`, "-s", `"d e",f\\g`, "-w", `x\ y z`}
+	want := map[string][]string{
+		"s": {"a,b", "c", "d e", `f\g`},
+		"w": {"x y", "z"},
+	}
+
+	for _, format := range []Format{INI, JSON} {
+		fs := flag.NewFlagSet("save", flag.ContinueOnError)
+		StringSet(fs, "s", "", "split on commas").Split()
+		StringSet(fs, "w", "", "split on spaces").WithDelimiter(' ')
+		if err := Parse(fs, args); err != nil {
+			t.Fatalf("format %d: Parse: %v", format, err)
+		}
+
+		var buf bytes.Buffer
+		if err := SaveConfig(fs, &buf, format); err != nil {
+			t.Fatalf("format %d: SaveConfig: %v", format, err)
+		}
+
+		loaded := flag.NewFlagSet("load", flag.ContinueOnError)
+		values := map[string]*Value{
+			"s": StringSet(loaded, "s", "", "split on commas").Split(),
+			"w": StringSet(loaded, "w", "", "split on spaces").WithDelimiter(' '),
+		}
+		var err error
+		if format == INI {
+			err = ApplyINI(loaded, &buf)
+		} else {
+			var config map[string]interface{}
+			if err = json.Unmarshal(buf.Bytes(), &config); err == nil {
+				err = ApplyConfig(loaded, config)
+			}
+		}
+		if err != nil {
+			t.Fatalf("format %d: loading %q: %v", format, buf.String(), err)
+		}
+
+		for name, v := range values {
+			if got := v.Args(); !reflect.DeepEqual(got, want[name]) {
+				t.Errorf("format %d: -%s = %q after round trip, want %q", format, name, got, want[name])
+			}
+		}
+	}
+}
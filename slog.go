@@ -0,0 +1,42 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"log/slog"
+)
+
+// SlogLevel maps the count of the Bool v, as given by Count, onto a log/slog level:
+// 0 is slog.LevelWarn, 1 is slog.LevelInfo, 2 is slog.LevelDebug and 3 or more is slog.LevelDebug-4.
+// A negative count, of a Signed value, is slog.LevelError.
+func (v *Value) SlogLevel() slog.Level {
+	switch n := v.Count(); {
+	case n < 0:
+		return slog.LevelError
+	case n == 0:
+		return slog.LevelWarn
+	case n == 1:
+		return slog.LevelInfo
+	case n == 2:
+		return slog.LevelDebug
+	default:
+		return slog.LevelDebug - 4
+	}
+}
+
+// BindLevelVar sets lv to the SlogLevel of v, and updates it whenever the arguments of v change,
+// as when they are parsed or reloaded, so that a handler created with lv follows the verbosity.
+// BindLevelVar returns v to permit chaining.
+func (v *Value) BindLevelVar(lv *slog.LevelVar) *Value {
+	prev := v.bind
+	v.bind = func() {
+		if prev != nil {
+			prev()
+		}
+		lv.Set(v.SlogLevel())
+	}
+	v.bind()
+	return v
+}
@@ -0,0 +1,68 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+)
+
+// Source identifies where a collected argument came from.
+type Source int
+
+const (
+	SourceConfig Source = iota // a configuration file, see ApplyConfig
+	SourceEnv                  // an environment variable, see BindEnv
+	SourceFlag                 // the command line
+	SourceAppend               // a call to Append
+)
+
+var sourceNames = []string{
+	SourceConfig: "config file",
+	SourceEnv:    "environment",
+	SourceFlag:   "command line",
+	SourceAppend: "program",
+}
+
+// String returns a short description of s.
+func (s Source) String() string {
+	if s < 0 || int(s) >= len(sourceNames) {
+		return "unknown"
+	}
+	return sourceNames[s]
+}
+
+// rank orders sources for Precedence. SourceAppend ranks with SourceFlag.
+func (s Source) rank() int {
+	if s == SourceAppend {
+		return int(SourceFlag)
+	}
+	return int(s)
+}
+
+// Occurrence is a collected argument and its provenance.
+type Occurrence struct {
+	Value  string // the argument, after any splitting
+	Source Source // where the argument came from
+	Origin string // the environment variable or file name, if known, for SourceEnv and SourceConfig, or the flag name or alias, for SourceFlag
+}
+
+// Occurrences returns the collected arguments, with their provenance, in the order of Args.
+// Unlike Args, it also reports the arguments of a Bool. Each argument from the command line
+// records whether it was given under the flag name or an alias, as in -v or -verbose.
+func (v *Value) Occurrences() []Occurrence {
+	return v.effective()
+}
+
+// CollectFrom calls fn, during which the arguments given to the Set methods of the multiflag values in fs,
+// as by a package that fills flags from the environment or configuration files, are recorded
+// as coming from src and origin, as for Occurrence, rather than the command line.
+// They are then subject to Precedence, so that, for example, a value from a configuration file
+// does not add to those given on the command line.
+func CollectFrom(fs *flag.FlagSet, src Source, origin string, fn func() error) error {
+	via := &Occurrence{Source: src, Origin: origin}
+	visit(fs, func(v *Value) { v.via = via })
+	defer visit(fs, func(v *Value) { v.via = nil })
+	return fn()
+}
@@ -0,0 +1,167 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Split causes each argument to be split on commas into multiple values,
+// so that "-trace parse,compile" is equivalent to "-trace parse -trace compile".
+// See WithDelimiter for escaping and quoting rules.
+// Boolean values are never split.
+// Split returns v to permit chaining.
+func (v *Value) Split() *Value {
+	v.raw = false
+	v.sep = ","
+	return v
+}
+
+// WithDelimiter causes each argument to be split on sep into multiple values.
+// If sep is a space character, arguments are split on runs of white space.
+// A value may contain a literal delimiter that is escaped with a backslash or enclosed in double quotes,
+// as in "a\,b" or "\"a,b\"".
+// Boolean values are never split.
+// WithDelimiter returns v to permit chaining.
+func (v *Value) WithDelimiter(sep rune) *Value {
+	v.raw = false
+	v.sep = string(sep)
+	return v
+}
+
+// DefaultDelimiter, if not zero, is the delimiter used to split the arguments of
+// non-boolean values that have not been configured with Split, WithDelimiter, PathList or NoSplit.
+// It is consulted as arguments are parsed.
+var DefaultDelimiter rune
+
+// DefaultTrimSpace denotes whether leading and trailing white space is removed from
+// the values of non-boolean flags that have not been configured with NoSplit.
+// It is consulted as arguments are parsed.
+var DefaultTrimSpace bool
+
+// NoSplit causes arguments to be collected exactly as given, regardless of
+// DefaultDelimiter and DefaultTrimSpace. It also cancels Split, WithDelimiter and PathList.
+// NoSplit returns v to permit chaining.
+func (v *Value) NoSplit() *Value {
+	v.raw = true
+	v.sep = ""
+	v.paths = false
+	return v
+}
+
+// split returns the values contained in a command line argument.
+func (v *Value) split(s string) ([]string, error) {
+	return v.splitWith(s, DefaultDelimiter)
+}
+
+// splitWith returns the values contained in an argument,
+// using dflt as the delimiter if v does not specify its own.
+func (v *Value) splitWith(s string, dflt rune) ([]string, error) {
+	if v.isBool || v.raw {
+		return []string{s}, nil
+	}
+	if v.paths {
+		return splitPathList(s), nil
+	}
+
+	args := []string{s}
+	if sep, ok := v.delimiter(dflt); ok {
+		var err error
+		args, err = splitQuoted(s, sep)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if DefaultTrimSpace {
+		for i, arg := range args {
+			args[i] = strings.TrimSpace(arg)
+		}
+	}
+	return args, nil
+}
+
+// delimiter returns the delimiter on which splitWith splits the arguments of v, given dflt,
+// and reports false if they are not split on a delimiter.
+func (v *Value) delimiter(dflt rune) (rune, bool) {
+	if v.isBool || v.raw || v.paths {
+		return 0, false
+	}
+	if v.sep != "" {
+		return []rune(v.sep)[0], true
+	}
+	return dflt, dflt != 0
+}
+
+// escapeQuoted returns s with a backslash before each delimiter, double quote and backslash,
+// so that splitQuoted, with sep, returns s as a single value. An empty s is quoted
+// if sep is a space character, since splitQuoted would otherwise find no value.
+func escapeQuoted(s string, sep rune) string {
+	space := unicode.IsSpace(sep)
+	if s == "" && space {
+		return `""`
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if r == '\\' || r == '"' || r == sep || space && unicode.IsSpace(r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// splitQuoted splits s on sep, or on runs of white space if sep is a space character.
+// A delimiter is taken literally if it is preceded by a backslash or is inside double quotes.
+// A backslash also escapes a double quote or another backslash; before any other character
+// it is an ordinary character. Unescaped double quotes are removed.
+func splitQuoted(s string, sep rune) ([]string, error) {
+	space := unicode.IsSpace(sep)
+	isSep := func(r rune) bool {
+		if space {
+			return unicode.IsSpace(r)
+		}
+		return r == sep
+	}
+
+	var out []string
+	var cur strings.Builder
+	quoted, escaped, pending := false, false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			if r != '"' && r != '\\' && !isSep(r) {
+				cur.WriteRune('\\')
+			}
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped, pending = true, true
+		case r == '"':
+			quoted, pending = !quoted, true
+		case !quoted && isSep(r):
+			if pending || !space {
+				out = append(out, cur.String())
+			}
+			cur.Reset()
+			pending = false
+		default:
+			cur.WriteRune(r)
+			pending = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf(messages.UnterminatedQuote, s)
+	}
+	if escaped {
+		cur.WriteRune('\\')
+	}
+	if pending || !space {
+		out = append(out, cur.String())
+	}
+	return out, nil
+}
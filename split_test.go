@@ -0,0 +1,56 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitQuoted(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		sep  rune
+		want []string
+		err  bool
+	}{
+		{s: "a,b", sep: ',', want: []string{"a", "b"}},
+		{s: "", sep: ',', want: []string{""}},
+		{s: "a,,b,", sep: ',', want: []string{"a", "", "b", ""}},
+		{s: `a\,b,c`, sep: ',', want: []string{"a,b", "c"}},
+		{s: `"a,b",c`, sep: ',', want: []string{"a,b", "c"}},
+		{s: `a"b,c"d,e`, sep: ',', want: []string{"ab,cd", "e"}},
+		{s: `a\"b,c\\d`, sep: ',', want: []string{`a"b`, `c\d`}},
+		{s: `a\xb`, sep: ',', want: []string{`a\xb`}},
+		{s: `a\`, sep: ',', want: []string{`a\`}},
+		{s: `"a,b`, sep: ',', err: true},
+		{s: "a;b,c", sep: ';', want: []string{"a", "b,c"}},
+		{s: "  a \t b\n", sep: ' ', want: []string{"a", "b"}},
+		{s: "", sep: ' ', want: nil},
+		{s: `a\ b c`, sep: ' ', want: []string{"a b", "c"}},
+		{s: `"" a`, sep: ' ', want: []string{"", "a"}},
+		{s: `"a b" c`, sep: ' ', want: []string{"a b", "c"}},
+	} {
+		got, err := splitQuoted(tc.s, tc.sep)
+		if (err != nil) != tc.err {
+			t.Errorf("splitQuoted(%q, %q): unexpected error %v", tc.s, tc.sep, err)
+			continue
+		}
+		if !tc.err && !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("splitQuoted(%q, %q): got %q, want %q", tc.s, tc.sep, got, tc.want)
+		}
+	}
+}
+
+func TestEscapeQuoted(t *testing.T) {
+	for _, sep := range []rune{',', ' ', ';'} {
+		for _, s := range []string{"", "plain", "a,b", "a b\tc", `"quoted"`, `back\slash`, `trailing\`, "a;b", ",", " "} {
+			got, err := splitQuoted(escapeQuoted(s, sep), sep)
+			if err != nil || len(got) != 1 || got[0] != s {
+				t.Errorf("sep %q: %q escaped as %q splits into %q, %v", sep, s, escapeQuoted(s, sep), got, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldSpec describes the flag for a struct field, as given by its tags.
+type fieldSpec struct {
+	name     string
+	aliases  []string
+	usage    string
+	value    string
+	env      string
+	required bool
+	count    bool
+}
+
+// parseFieldTags returns the flag for sf, described by tags in the formats of github.com/alexflint/go-arg,
+// such as arg:"-v,--verbose,env:VERBOSE" help:"..." default:"...", and github.com/caarlos0/env,
+// such as env:"VERBOSE" envDefault:"...". It reports false if sf has neither an arg nor an env tag,
+// or is not a flag, as for arg:"-" or arg:"positional".
+func parseFieldTags(sf reflect.StructField) (fieldSpec, bool) {
+	arg, hasArg := sf.Tag.Lookup("arg")
+	env, hasEnv := sf.Tag.Lookup("env")
+	if !hasArg && !hasEnv || arg == "-" {
+		return fieldSpec{}, false
+	}
+	spec := fieldSpec{usage: sf.Tag.Get("help"), value: sf.Tag.Get("default")}
+	if hasEnv {
+		spec.env = strings.SplitN(env, ",", 2)[0]
+	}
+	if v, ok := sf.Tag.Lookup("envDefault"); ok && spec.value == "" {
+		spec.value = v
+	}
+	var shorts []string
+	for _, item := range strings.Split(arg, ",") {
+		switch item = strings.TrimSpace(item); {
+		case item == "":
+		case strings.HasPrefix(item, "--"):
+			if spec.name == "" {
+				spec.name = item[2:]
+			} else {
+				spec.aliases = append(spec.aliases, item[2:])
+			}
+		case strings.HasPrefix(item, "-"):
+			shorts = append(shorts, item[1:])
+		case item == "required":
+			spec.required = true
+		case item == "env":
+			spec.env = strings.ToUpper(sf.Name)
+		case strings.HasPrefix(item, "env:"):
+			spec.env = item[4:]
+		case item == "positional", strings.HasPrefix(item, "subcommand"):
+			return fieldSpec{}, false
+		}
+	}
+	if spec.name == "" {
+		spec.name = strings.ToLower(sf.Name)
+	}
+	spec.aliases = append(spec.aliases, shorts...)
+	spec.count = sf.Tag.Get("multiflag") == "count"
+	return spec, true
+}
+
+// BindStruct registers, in fs, a multiflag value for each exported field of the struct pointed to by dst
+// that has a tag of github.com/alexflint/go-arg or github.com/caarlos0/env, so that such a struct can be filled
+// by multiflag, with repeat counting and aliases, while a program migrates to or from those packages.
+// The first long name in the arg tag is the flag name, and defaults to the lower cased field name;
+// other names, including short ones, are aliases. The help and default tags give the usage and default value,
+// an env option or tag names an environment variable that is applied at once, with SourceEnv,
+// and the required option is the same as Required.
+//
+// A field is updated as each argument is parsed. A string, bool or int field holds the last value
+// and a []string field holds every value. An int field tagged multiflag:"count" instead holds the count
+// of a Bool, so that -v -v -v sets it to 3. Fields of other types cause an error.
+func BindStruct(fs *flag.FlagSet, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("multiflag: BindStruct requires a pointer to a struct, not %T", dst)
+	}
+	rv = rv.Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		sf := rv.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		spec, ok := parseFieldTags(sf)
+		if !ok {
+			continue
+		}
+		if err := bindField(fs, rv.Field(i), spec); err != nil {
+			return fmt.Errorf("multiflag: field %s: %v", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkDefault returns an error if the default value in spec is not accepted by check.
+// The constructors panic on an invalid default, which, in a tag, is instead reported by BindStruct.
+func (spec fieldSpec) checkDefault(check func(string) error) error {
+	if spec.value == "" {
+		return nil
+	}
+	if err := check(spec.value); err != nil {
+		return fmt.Errorf("invalid default %q: %v", spec.value, err)
+	}
+	return nil
+}
+
+// checkCount returns an error if s is not a valid argument for a Bool.
+func checkCount(s string) error {
+	_, _, err := parseCount(s)
+	return err
+}
+
+// bindField registers a multiflag value, as described by spec, that updates field.
+func bindField(fs *flag.FlagSet, field reflect.Value, spec fieldSpec) error {
+	var v *Value
+	var bind func()
+	last := func() string {
+		args := v.ArgsOrDefault()
+		if len(args) == 0 {
+			return ""
+		}
+		return args[len(args)-1]
+	}
+	switch field.Kind() {
+	case reflect.Bool:
+		if err := spec.checkDefault(checkCount); err != nil {
+			return err
+		}
+		v = newBool(fs.Var, spec.name, spec.value, spec.usage, spec.aliases...)
+		bind = func() { field.SetBool(v.BoolValue()) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if spec.count {
+			if err := spec.checkDefault(checkCount); err != nil {
+				return err
+			}
+			v = newBool(fs.Var, spec.name, spec.value, spec.usage, spec.aliases...)
+			bind = func() { field.SetInt(int64(v.Count())) }
+			break
+		}
+		if err := spec.checkDefault(func(s string) error {
+			_, err := strconv.Atoi(s)
+			return err
+		}); err != nil {
+			return err
+		}
+		v = newInt(fs.Var, spec.name, spec.value, spec.usage, spec.aliases...).KeepLast()
+		bind = func() {
+			n, _ := strconv.ParseInt(last(), 10, 64)
+			field.SetInt(n)
+		}
+	case reflect.String:
+		v = newString(fs.Var, spec.name, spec.value, spec.usage, spec.aliases...).KeepLast()
+		bind = func() { field.SetString(last()) }
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+		v = newString(fs.Var, spec.name, spec.value, spec.usage, spec.aliases...)
+		bind = func() { field.Set(reflect.ValueOf(v.ArgsOrDefault()).Convert(field.Type())) }
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	if spec.required {
+		v.Required()
+	}
+	v.bind = bind
+	if spec.env != "" {
+		if s, ok := os.LookupEnv(spec.env); ok {
+			if err := v.setEnv(spec.env, s); err != nil {
+				return fmt.Errorf(messages.InvalidEnv, s, spec.env, err)
+			}
+		}
+	}
+	v.bind()
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// FlagGroup is a named group of flags; see WithGroup.
+type FlagGroup struct {
+	Name  string
+	Flags []FlagInfo
+}
+
+// UsageData is the data available to a usage template.
+type UsageData struct {
+	FlagSet   *flag.FlagSet
+	Name      string      // the FlagSet name
+	Flags     []FlagInfo  // all flags
+	Ungrouped []FlagInfo  // flags that are not in a group
+	Groups    []FlagGroup // groups of flags, in the order in which they are first found
+}
+
+// templateFuncs are the functions available to a usage template, in addition to the standard ones.
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"wrap": func(width int, indent int, s string) string {
+		return strings.Join(wrap(s, width), "\n"+strings.Repeat(" ", indent))
+	},
+	"defaults": func(fs *flag.FlagSet, flags []FlagInfo) string { return RenderDefaults(fs, flags) },
+}
+
+// TemplateRenderer returns a UsageRenderer, for use with SetUsageRenderer, that executes the
+// text/template text with a UsageData. Besides the standard functions, the template may use
+// join, upper, wrap (width, indent, text) and defaults, which takes a FlagSet and a list of flags
+// and returns the output of RenderDefaults. For example:
+//
+//	Usage of {{.Name}}:
+//	{{defaults .FlagSet .Ungrouped}}{{range .Groups}}
+//	{{upper .Name}}
+//	{{range .Flags}}  {{join .Names ", "}}
+//	      {{wrap 60 6 .Description}}
+//	{{end}}{{end}}
+func TemplateRenderer(text string) (UsageRenderer, error) {
+	tmpl, err := template.New("usage").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(fs *flag.FlagSet, flags []FlagInfo) string {
+		data := UsageData{FlagSet: fs, Name: fs.Name(), Flags: flags}
+		var groups []string
+		var byGroup map[string][]FlagInfo
+		data.Ungrouped, groups, byGroup = groupFlags(flags)
+		for _, group := range groups {
+			data.Groups = append(data.Groups, FlagGroup{Name: group, Flags: byGroup[group]})
+		}
+
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			fmt.Fprintf(&b, "\nusage template: %v\n", err)
+		}
+		return b.String()
+	}, nil
+}
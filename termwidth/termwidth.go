@@ -0,0 +1,31 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package termwidth reports the width of a terminal with golang.org/x/term, so that multiflag
+wraps usage text to the terminal it is written to:
+
+	multiflag.SetTerminalWidth(termwidth.Width)
+*/
+package termwidth
+
+import (
+	"io"
+
+	"golang.org/x/term"
+)
+
+// Width returns the width, in columns, of the terminal that w writes to,
+// or zero if w is not a terminal, as when it is a file or a buffer.
+func Width(w io.Writer) int {
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok {
+		return 0
+	}
+	n, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return n
+}
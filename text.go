@@ -0,0 +1,50 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"encoding"
+	"flag"
+)
+
+// TextValue collects repeated flag arguments that are decoded by an encoding.TextUnmarshaler.
+type TextValue struct {
+	*Value
+	newT func() encoding.TextUnmarshaler
+}
+
+func newText(fn Flagger, name string, value string, usage string, newT func() encoding.TextUnmarshaler, aliases ...string) *TextValue {
+	t := &TextValue{Value: newString(fn, name, value, usage, aliases...), newT: newT}
+	t.check = func(s string) error {
+		return newT().UnmarshalText([]byte(s))
+	}
+	t.checkDefaults()
+	return t
+}
+
+// Text returns a multiflag instance, associated with flag, whose arguments are decoded by
+// an encoding.TextUnmarshaler. newT is called to produce a fresh value for each argument.
+// Arguments that fail to decode are rejected when the flag is parsed.
+func Text(name string, value string, usage string, newT func() encoding.TextUnmarshaler, aliases ...string) *TextValue {
+	return newText(flag.Var, name, value, usage, newT, aliases...)
+}
+
+// TextSet creates a Text multiflag instance, associates it with the provided FlagSet and returns it.
+func TextSet(flg *flag.FlagSet, name string, value string, usage string, newT func() encoding.TextUnmarshaler, aliases ...string) *TextValue {
+	return newText(flg.Var, name, value, usage, newT, aliases...)
+}
+
+// Values returns the decoded arguments, in the order they were given.
+func (t *TextValue) Values() []encoding.TextUnmarshaler {
+	args := t.Args()
+	vals := make([]encoding.TextUnmarshaler, 0, len(args))
+	for _, arg := range args {
+		u := t.newT()
+		if err := u.UnmarshalText([]byte(arg)); err == nil {
+			vals = append(vals, u)
+		}
+	}
+	return vals
+}
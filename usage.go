@@ -0,0 +1,499 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// HideAllAliases causes PrintDefaults to omit the aliases of all multiflag values.
+var HideAllAliases bool
+
+// HideAliases causes PrintDefaults to omit the aliases of v, leaving only its name.
+// The aliases are still accepted on the command line.
+// HideAliases returns v to permit chaining.
+func (v *Value) HideAliases() *Value {
+	v.hideAliases = true
+	return v
+}
+
+// Hidden causes v, and its aliases, to be omitted from Flags and therefore from usage output,
+// generated documentation and completion. The flag is still accepted on the command line.
+// Hidden returns v to permit chaining.
+func (v *Value) Hidden() *Value {
+	v.hidden = true
+	return v
+}
+
+// WithGroup assigns v to a named group. RenderDefaults shows each group, under a heading,
+// after the flags that are not in a group.
+// WithGroup returns v to permit chaining.
+func (v *Value) WithGroup(group string) *Value {
+	v.group = group
+	return v
+}
+
+// WithExample adds an example invocation, such as "-t parse -t compile", to the usage output for v.
+// It may be called more than once.
+// WithExample returns v to permit chaining.
+func (v *Value) WithExample(example string) *Value {
+	v.examples = append(v.examples, example)
+	return v
+}
+
+// Order determines the order of flags in usage output.
+type Order int
+
+const (
+	Lexicographic Order = iota // flags in lexicographical order of name, as in the flag package
+	Registration               // multiflag values in the order they were created, then other flags lexicographically
+	ByGroup                    // flags that are not in a group, then each group in the order of its first value's creation
+)
+
+// UsageOrder is the order of the flags described by Flags and therefore of usage output.
+// Within a group, ByGroup orders flags lexicographically.
+var UsageOrder Order
+
+// FlagInfo describes a flag for usage output.
+type FlagInfo struct {
+	Name              string            // flag name
+	Aliases           []string          // alternate names of a multiflag value, unless hidden
+	Placeholder       string            // argument placeholder, empty for boolean flags; see flag.UnquoteUsage
+	Usage             string            // usage text, without the placeholder quotes or RepeatableMarker
+	Default           string            // default value, empty if it is zero and not worth showing
+	Type              string            // value type, such as "string", "bool" or "int"
+	Repeatable        bool              // denotes a multiflag value
+	Group             string            // usage output section, if any; see WithGroup
+	Deprecated        bool              // denotes a deprecated flag; see Deprecate
+	Deprecation       string            // reason the flag is deprecated, if any
+	DeprecatedAliases map[string]string // deprecated aliases, with reasons; see DeprecateAlias
+	Defaults          []string          // declared default values; see StringWithDefaults
+	Current           []string          // current values, or the count of a Bool; empty if there are none
+	Examples          []string          // example invocations; see WithExample
+	Hint              ValueHint         // kind of values, for completion
+	Negations         []string          // negating flags; see Negatable
+	Dynamic           bool              // denotes values completed at run time; see Complete
+	Choices           []string          // permitted values, if restricted; see WithChoices
+	Required          bool              // denotes a required flag; see Required
+	Pattern           string            // regular expression permitted values match, if any; see WithPattern
+}
+
+// UsageRenderer produces usage output for the flags in fs, as described by flags.
+type UsageRenderer func(fs *flag.FlagSet, flags []FlagInfo) string
+
+// usageRenderer, if set, replaces RenderDefaults in PrintDefaults.
+var usageRenderer UsageRenderer
+
+// SetUsageRenderer causes PrintDefaults, and therefore Usage, to print the output of r.
+// A nil r restores RenderDefaults.
+func SetUsageRenderer(r UsageRenderer) {
+	usageRenderer = r
+}
+
+// UsageWidth is the width, in columns, to which RenderDefaults wraps usage text.
+// If it is zero, the width is that of the terminal the FlagSet writes to, if it is one and SetTerminalWidth
+// has been called. Otherwise, it is taken from the COLUMNS environment variable, which shells set
+// but do not usually export, or is 80. A negative width disables wrapping.
+var UsageWidth int
+
+// terminalWidth, if set, reports the width of the terminal written to by w; see SetTerminalWidth.
+var terminalWidth func(w io.Writer) int
+
+// SetTerminalWidth causes RenderDefaults, if UsageWidth is zero, to wrap usage text to the width that fn
+// reports for the output of the FlagSet, so that help written to a file or buffer is not wrapped to the terminal.
+// fn returns zero if its argument is not a terminal. Querying a terminal requires code beyond the
+// standard library, which termwidth.Width, in the termwidth package, provides:
+//
+//	multiflag.SetTerminalWidth(termwidth.Width)
+func SetTerminalWidth(fn func(w io.Writer) int) {
+	terminalWidth = fn
+}
+
+// usageWidth returns the effective UsageWidth for usage text written to out.
+func usageWidth(out io.Writer) int {
+	if UsageWidth != 0 {
+		return UsageWidth
+	}
+	if terminalWidth != nil {
+		if n := terminalWidth(out); n > 0 {
+			return n
+		}
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 80
+}
+
+// minDescWidth is the narrowest column in which RenderDefaults places usage text beside flag names.
+// Narrower columns cause usage text to be placed below the names.
+const minDescWidth = 30
+
+// wrap breaks text into lines no longer than width, in runes, where possible, at white space.
+// Newlines in text are preserved. A width less than one disables wrapping.
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		if width < 1 {
+			lines = append(lines, para)
+			continue
+		}
+		line := ""
+		for _, word := range strings.Fields(para) {
+			if line != "" && utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// isZeroDefault reports whether a default value is not worth showing.
+func isZeroDefault(s string) bool {
+	return s == "" || s == "0" || s == "false"
+}
+
+// flagType returns the type name of an ordinary flag's value.
+func flagType(f *flag.Flag) string {
+	if t, ok := f.Value.(interface{ Type() string }); ok {
+		return t.Type()
+	}
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return "bool"
+	}
+	name := fmt.Sprintf("%T", f.Value)
+	return strings.TrimSuffix(strings.TrimPrefix(name, "*flag."), "Value")
+}
+
+// Flags describes the flags in fs, in the order given by UsageOrder.
+// The aliases and negating flags of a multiflag value are included with its name, rather than as separate flags.
+// Hidden values are omitted.
+func Flags(fs *flag.FlagSet) []FlagInfo {
+	var infos []FlagInfo
+	seqs := make(map[string]int)
+	fs.VisitAll(func(f *flag.Flag) {
+		if _, ok := f.Value.(*negation); ok {
+			return
+		}
+		arg, usage := flag.UnquoteUsage(f)
+		info := FlagInfo{Name: f.Name, Placeholder: arg, Usage: usage, Type: flagType(f)}
+		if !isZeroDefault(f.DefValue) {
+			info.Default = f.DefValue
+			info.Defaults = []string{f.DefValue}
+		}
+		if s := f.Value.String(); s != f.DefValue {
+			info.Current = []string{s}
+		}
+		if v, ok := valueOf(f); ok {
+			if f.Name != v.name || v.hidden {
+				return
+			}
+			if !v.hideAliases && !HideAllAliases {
+				info.Aliases = v.aliases
+			}
+			_, info.Usage = flag.UnquoteUsage(&flag.Flag{Usage: v.usage, Value: v})
+			if arg == "value" && v.typ != "" {
+				// the placeholder the flag package gives a flag of the type, as float for float64
+				info.Placeholder = strings.TrimSuffix(v.typ, "64")
+			}
+			info.Repeatable = true
+			seqs[info.Name] = v.seq
+			info.Group = v.group
+			info.Examples = v.examples
+			info.Hint = v.hint
+			info.Negations = v.negations
+			info.Dynamic = v.complete != nil
+			info.Choices = v.choices
+			info.Required = v.required
+			if v.pattern != nil {
+				info.Pattern = v.pattern.String()
+			}
+			if v.isBool {
+				info.Current = nil
+				if n := v.NArg(); n > 0 {
+					info.Current = []string{strconv.Itoa(n)}
+				}
+			} else {
+				info.Defaults, info.Current = v.defaults(), v.Args()
+			}
+			info.Deprecated, info.Deprecation = v.deprecated, v.deprecation
+			for _, alias := range info.Aliases {
+				if reason, ok := v.deprecatedAliases[alias]; ok {
+					if info.DeprecatedAliases == nil {
+						info.DeprecatedAliases = make(map[string]string)
+					}
+					info.DeprecatedAliases[alias] = reason
+				}
+			}
+		}
+		infos = append(infos, info)
+	})
+	sortFlags(infos, seqs)
+	return infos
+}
+
+// sortFlags reorders flags, which are in lexicographical order, as given by UsageOrder.
+// seqs holds the registration order of the multiflag values among them.
+func sortFlags(flags []FlagInfo, seqs map[string]int) {
+	switch UsageOrder {
+	case Registration:
+		rank := func(name string) int {
+			if seq, ok := seqs[name]; ok {
+				return seq
+			}
+			return math.MaxInt
+		}
+		sort.SliceStable(flags, func(i, j int) bool { return rank(flags[i].Name) < rank(flags[j].Name) })
+	case ByGroup:
+		first := map[string]int{"": 0}
+		for _, info := range flags {
+			if seq, ok := first[info.Group]; !ok || seqs[info.Name] < seq {
+				first[info.Group] = seqs[info.Name]
+			}
+		}
+		sort.SliceStable(flags, func(i, j int) bool { return first[flags[i].Group] < first[flags[j].Group] })
+	}
+}
+
+// Names returns the flag names of info, with one letter aliases first, each with a leading dash.
+func (info FlagInfo) Names() []string {
+	var short, long []string
+	for _, alias := range info.Aliases {
+		if len(alias) == 1 {
+			short = append(short, "-"+alias)
+		} else {
+			long = append(long, "-"+alias)
+		}
+	}
+	return append(append(short, "-"+info.Name), long...)
+}
+
+// Description returns the usage text of info, with the annotations shown by RenderDefaults.
+func (info FlagInfo) Description() string {
+	parts := []string{info.Usage}
+	if info.Repeatable && RepeatableMarker != "" {
+		parts = append(parts, RepeatableMarker)
+	}
+	if len(info.Choices) > 0 {
+		parts = append(parts, fmt.Sprintf(messages.Choices, strings.Join(info.Choices, ", ")))
+	}
+	if info.Pattern != "" {
+		parts = append(parts, fmt.Sprintf(messages.Pattern, info.Pattern))
+	}
+	if info.Required {
+		parts = append(parts, messages.Required)
+	}
+	if len(info.Negations) > 0 {
+		names := make([]string, len(info.Negations))
+		for i, name := range info.Negations {
+			names[i] = "-" + name
+		}
+		parts = append(parts, fmt.Sprintf(messages.Negation, strings.Join(names, ", ")))
+	}
+	if info.Default != "" {
+		if info.Type == "string" {
+			parts = append(parts, fmt.Sprintf(messages.Default, strconv.Quote(info.Default)))
+		} else {
+			parts = append(parts, fmt.Sprintf(messages.Default, info.Default))
+		}
+	}
+	for _, note := range info.deprecationNotes() {
+		parts = append(parts, "("+note+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// deprecationNotes returns a note for the deprecation of info and of each of its deprecated aliases.
+func (info FlagInfo) deprecationNotes() []string {
+	var notes []string
+	if info.Deprecated {
+		notes = append(notes, deprecationText(info.Deprecation))
+	}
+	for _, alias := range info.Aliases {
+		if reason, ok := info.DeprecatedAliases[alias]; ok {
+			notes = append(notes, fmt.Sprintf(messages.DeprecatedAlias, alias, deprecationText(reason)))
+		}
+	}
+	return notes
+}
+
+// groupFlags splits flags into those without a group and those in each group,
+// with groups in the order in which they are first found.
+func groupFlags(flags []FlagInfo) (ungrouped []FlagInfo, groups []string, byGroup map[string][]FlagInfo) {
+	byGroup = make(map[string][]FlagInfo)
+	for _, info := range flags {
+		if info.Group == "" {
+			ungrouped = append(ungrouped, info)
+			continue
+		}
+		if _, ok := byGroup[info.Group]; !ok {
+			groups = append(groups, info.Group)
+		}
+		byGroup[info.Group] = append(byGroup[info.Group], info)
+	}
+	return
+}
+
+// RenderDefaults is the default UsageRenderer. It produces a line for each flag showing its names,
+// argument placeholder, usage and default value, with the usage in an aligned column, as in
+//
+//	-t, -trace value   Trace program sections (may be repeated) (default "none")
+//
+// Usage text is wrapped to UsageWidth, measured in runes; if there is too little room beside the names,
+// it is placed on the following lines. Examples, if any, follow the usage text, each on its own line.
+// Flags in a group follow the others, under a heading naming the group.
+// Groups appear in the order in which they are first found in flags.
+func RenderDefaults(fs *flag.FlagSet, flags []FlagInfo) string {
+	lefts := make(map[string]string)
+	width := 0
+	for _, info := range flags {
+		left := strings.Join(info.Names(), ", ")
+		if info.Placeholder != "" {
+			left += " " + info.Placeholder
+		}
+		lefts[info.Name] = left
+		if n := utf8.RuneCountInString(left); n > width {
+			width = n
+		}
+	}
+	ungrouped, groups, byGroup := groupFlags(flags)
+
+	total := usageWidth(fs.Output())
+	indent := 2 + width + 3
+	below := total > 0 && total-indent < minDescWidth
+	if below {
+		indent = 8
+	}
+	descWidth := total - indent
+	if total < 0 {
+		descWidth = 0
+	}
+
+	var b strings.Builder
+	line := func(info FlagInfo) {
+		desc := wrap(info.Description(), descWidth)
+		for _, example := range info.Examples {
+			desc = append(desc, fmt.Sprintf(messages.Example, example))
+		}
+		if below {
+			fmt.Fprintf(&b, "  %s\n", lefts[info.Name])
+		} else {
+			fmt.Fprintf(&b, "  %-*s   %s\n", width, lefts[info.Name], desc[0])
+			desc = desc[1:]
+		}
+		for _, d := range desc {
+			fmt.Fprintf(&b, "%*s%s\n", indent, "", d)
+		}
+	}
+
+	for _, info := range ungrouped {
+		line(info)
+	}
+	for _, group := range groups {
+		fmt.Fprintf(&b, "\n%s:\n", group)
+		for _, info := range byGroup[group] {
+			line(info)
+		}
+	}
+	return b.String()
+}
+
+// PrintDefaults prints, to fs.Output(), the usage output for the flags in fs produced by RenderDefaults
+// or the renderer given to SetUsageRenderer. Unlike (*flag.FlagSet).PrintDefaults, the aliases of a
+// multiflag value are shown with its name.
+func PrintDefaults(fs *flag.FlagSet) {
+	render := usageRenderer
+	if render == nil {
+		render = RenderDefaults
+	}
+	io.WriteString(fs.Output(), render(fs, Flags(fs)))
+}
+
+// Usage returns a function, suitable for assignment to fs.Usage, that prints a usage header and PrintDefaults.
+// For the command line, use
+//
+//	flag.Usage = multiflag.Usage(flag.CommandLine)
+func Usage(fs *flag.FlagSet) func() {
+	return func() {
+		if fs.Name() == "" {
+			fmt.Fprintf(fs.Output(), "Usage:\n")
+		} else {
+			fmt.Fprintf(fs.Output(), "Usage of %s:\n", fs.Name())
+		}
+		PrintDefaults(fs)
+	}
+}
+
+// RenderCurrent is a UsageRenderer that shows the values currently in effect, rather than usage text,
+// as for a -show-config flag. It produces a line for each flag showing its names and its current values,
+// or its default values, marked as such, if it has none, as in
+//
+//	-t, -trace     "parse", "compile"
+//	-v, -verbose   2
+//	-n             3 (default)
+//
+// Flags in a group follow the others, under a heading naming the group.
+func RenderCurrent(fs *flag.FlagSet, flags []FlagInfo) string {
+	width := 0
+	for _, info := range flags {
+		if n := utf8.RuneCountInString(strings.Join(info.Names(), ", ")); n > width {
+			width = n
+		}
+	}
+
+	format := func(values []string, typ string) string {
+		out := make([]string, len(values))
+		for i, s := range values {
+			if typ == "string" {
+				s = strconv.Quote(s)
+			}
+			out[i] = s
+		}
+		return strings.Join(out, ", ")
+	}
+
+	var b strings.Builder
+	line := func(info FlagInfo) {
+		value := "(not set)"
+		switch {
+		case len(info.Current) > 0:
+			value = format(info.Current, info.Type)
+		case len(info.Defaults) > 0:
+			value = format(info.Defaults, info.Type) + " (default)"
+		}
+		fmt.Fprintf(&b, "  %-*s   %s\n", width, strings.Join(info.Names(), ", "), value)
+	}
+
+	ungrouped, groups, byGroup := groupFlags(flags)
+	for _, info := range ungrouped {
+		line(info)
+	}
+	for _, group := range groups {
+		fmt.Fprintf(&b, "\n%s:\n", group)
+		for _, info := range byGroup[group] {
+			line(info)
+		}
+	}
+	return b.String()
+}
+
+// PrintCurrent prints, to fs.Output(), the values in effect for the flags in fs, as produced by RenderCurrent.
+func PrintCurrent(fs *flag.FlagSet) {
+	io.WriteString(fs.Output(), RenderCurrent(fs, Flags(fs)))
+}
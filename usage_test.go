@@ -0,0 +1,54 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWrapCountsRunes(t *testing.T) {
+	got := wrap("é é é é é é", 5)
+	want := []string{"é é é", "é é é"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestUsageWidthFromOutput(t *testing.T) {
+	defer SetTerminalWidth(nil)
+	var out bytes.Buffer
+	SetTerminalWidth(func(w io.Writer) int {
+		if w == &out {
+			return 40
+		}
+		return 0
+	})
+	t.Setenv("COLUMNS", "")
+
+	fs := flag.NewFlagSet("usage", flag.ContinueOnError)
+	fs.SetOutput(&out)
+	StringSet(fs, "trace", "", "étapes à tracer, données une par une ou séparées par des virgules")
+	PrintDefaults(fs)
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		if n := utf8.RuneCountInString(line); n > 40 {
+			t.Errorf("line of %d runes exceeds the terminal width: %q", n, line)
+		}
+	}
+	if lines := strings.Count(out.String(), "\n"); lines < 3 {
+		t.Errorf("usage not wrapped to the terminal width:\n%s", out.String())
+	}
+
+	out.Reset()
+	fs.SetOutput(io.Discard)
+	if got := usageWidth(fs.Output()); got != 80 {
+		t.Errorf("width of output that is not a terminal: got %d, want 80", got)
+	}
+}
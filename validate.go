@@ -0,0 +1,331 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WithValidator adds fn to the functions that validate each argument of v, from any source,
+// before it is collected. WithValidator panics if fn rejects a default value of v. An error from fn rejects the argument and, on the command line,
+// ends parsing with an error naming the flag and the argument.
+// Validators are called in the order they were added, after any validation done by the constructor.
+// WithValidator returns v to permit chaining.
+func (v *Value) WithValidator(fn func(string) error) *Value {
+	v.validators = append(v.validators, fn)
+	v.checkDefaults()
+	return v
+}
+
+// validate returns an error if arg is not a valid argument for v. Unless effects is true,
+// it skips a check with effects, such as the loading of the file named by a ConfigFile argument.
+func (v *Value) validate(arg string, effects bool) error {
+	if v.isBool {
+		if _, _, err := parseCount(arg); err != nil {
+			return err
+		}
+	}
+	if v.check != nil && (effects || !v.checkLoads) {
+		if err := v.check(arg); err != nil {
+			return err
+		}
+	}
+	if v.choices != nil && !contains(v.choices, arg) {
+		err := fmt.Errorf(messages.InvalidChoice, arg, strings.Join(v.choices, ", "))
+		if err := v.relax(arg, err); err != nil {
+			return err
+		}
+	}
+	for _, validate := range v.validators {
+		if err := v.relax(arg, validate(arg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDefaults panics if a default value of v is not a valid argument, so that the mistake is found
+// when v is created, or given a constraint, rather than when the default is first used.
+// A default is only checked, not applied, so a check with effects is skipped.
+func (v *Value) checkDefaults() {
+	for _, def := range v.defaults() {
+		if err := v.validate(def, false); err != nil {
+			panic(fmt.Sprintf("multiflag: invalid default %q for flag -%s: %v", def, v.name, err))
+		}
+	}
+	if v.checkBatch != nil {
+		if err := v.checkBatch(v.defaults()); err != nil {
+			panic(fmt.Sprintf("multiflag: invalid defaults %q for flag -%s: %v", v.defaults(), v.name, err))
+		}
+	}
+}
+
+// rules are the constraints between values, such as MutuallyExclusive, checked by Validate.
+var rules []func(fs *flag.FlagSet) error
+
+// registered reports whether v is registered in fs.
+func registered(fs *flag.FlagSet, v *Value) bool {
+	f := fs.Lookup(v.name)
+	if f == nil {
+		return false
+	}
+	u, _ := valueOf(f)
+	return u == v
+}
+
+// usedNames returns the names, with a leading dash, under which v was given on the command line,
+// or its name if it was not.
+func usedNames(fs *flag.FlagSet, v *Value) []string {
+	var names []string
+	fs.Visit(func(f *flag.Flag) {
+		if u, _ := valueOf(f); u == v {
+			names = append(names, "-"+f.Name)
+		}
+	})
+	if len(names) == 0 {
+		names = []string{"-" + v.name}
+	}
+	return names
+}
+
+// Validate checks the constraints of the multiflag values registered in fs, such as MinOccurrences,
+// and the constraints between them, such as MutuallyExclusive and those added by OnValidate,
+// once the arguments from all sources have been collected, typically after Parse.
+// It returns nil or an error, compatible with errors.Join, that reports every failed constraint.
+// Use flag.CommandLine for values created with the package level constructors.
+func Validate(fs *flag.FlagSet) error {
+	var errs []error
+	visit(fs, func(v *Value) {
+		for _, constraint := range v.constraints {
+			if err := constraint(); err != nil {
+				err = &FlagError{Name: v.name, Used: strings.TrimPrefix(usedNames(fs, v)[0], "-"), Err: err}
+				if v.warnOnly {
+					warnf("%v", err)
+				} else {
+					errs = append(errs, err)
+				}
+			}
+		}
+	})
+	for _, rule := range rules {
+		if err := rule(fs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MinOccurrences causes Validate to report an error if v has fewer than n values, as counted by NArg,
+// from all sources.
+// MinOccurrences returns v to permit chaining.
+func (v *Value) MinOccurrences(n int) *Value {
+	v.constraints = append(v.constraints, func() error {
+		if got := v.NArg(); got < n {
+			return fmt.Errorf(messages.TooFew, got, n)
+		}
+		return nil
+	})
+	return v
+}
+
+// WarnOnly causes the constraints of v, such as those of WithChoices, WithValidator, MaxOccurrences,
+// Required and Requires, to be reported as warnings, to WarningOutput, rather than errors,
+// so that a new restriction can be introduced without breaking existing command lines.
+// Arguments that fail a constraint are collected. Arguments that cannot be parsed, for typed values,
+// are still rejected.
+// WarnOnly returns v to permit chaining.
+func (v *Value) WarnOnly() *Value {
+	v.warnOnly = true
+	return v
+}
+
+// relax returns err, an error in arg or, if arg is empty, in the arguments of v or,
+// if v is WarnOnly, writes err as a warning and returns nil.
+func (v *Value) relax(arg string, err error) error {
+	if err == nil || !v.warnOnly {
+		return err
+	}
+	warnf("%v", &FlagError{Name: v.name, Used: v.name, Value: arg, HasValue: arg != "", Err: err})
+	return nil
+}
+
+// sameSource returns the values in occs that rank with src.
+func sameSource(occs []Occurrence, src Source) []string {
+	var same []string
+	for _, o := range occs {
+		if o.Source.rank() == src.rank() {
+			same = append(same, o.Value)
+		}
+	}
+	return same
+}
+
+// checkLimits checks args, from src, against the limits of v given the values already collected in occs.
+// It is called with mu held.
+func (v *Value) checkLimits(occs []Occurrence, args []string, src Source) error {
+	same := sameSource(occs, src)
+	if v.max > 0 && len(same)+len(args) > v.max {
+		return fmt.Errorf(messages.TooMany, len(same)+len(args), v.max)
+	}
+	if v.noDups {
+		for _, arg := range args {
+			if contains(same, arg) {
+				return fmt.Errorf(messages.DuplicateValue, arg)
+			}
+			same = append(same, arg)
+		}
+	}
+	return nil
+}
+
+// MaxOccurrences limits v to n values. A value that exceeds the limit, among those from the same source,
+// such as the command line, is rejected with an error, and Validate reports an error if v has more
+// than n values, as counted by NArg, from all sources.
+// MaxOccurrences returns v to permit chaining.
+func (v *Value) MaxOccurrences(n int) *Value {
+	v.max = n
+	v.constraints = append(v.constraints, func() error {
+		if got := v.NArg(); got > n {
+			return fmt.Errorf(messages.TooMany, got, n)
+		}
+		return nil
+	})
+	return v
+}
+
+// Required causes Validate to report an error if v has no values from any source,
+// and marks v as required in usage output.
+// Required returns v to permit chaining.
+func (v *Value) Required() *Value {
+	v.required = true
+	v.constraints = append(v.constraints, func() error {
+		if v.NArg() == 0 {
+			return errors.New(messages.Missing)
+		}
+		return nil
+	})
+	return v
+}
+
+// MutuallyExclusive causes Validate to report an error if more than one of values has a value,
+// as counted by NArg, from any source. The error names the flags, or the aliases, that were used.
+// The constraint applies to a FlagSet in which all of values are registered.
+// It is reported as a warning if all of values are WarnOnly.
+func MutuallyExclusive(values ...*Value) {
+	rules = append(rules, func(fs *flag.FlagSet) error {
+		var names []string
+		n, soft := 0, true
+		for _, v := range values {
+			if !registered(fs, v) {
+				return nil
+			}
+			if v.NArg() > 0 {
+				names = append(names, usedNames(fs, v)...)
+				n++
+			}
+			soft = soft && v.warnOnly
+		}
+		if n < 2 {
+			return nil
+		}
+		err := fmt.Errorf(messages.Exclusive, strings.Join(names, ", "))
+		if soft {
+			warnf("%v", err)
+			return nil
+		}
+		return err
+	})
+}
+
+// Requires causes Validate to report an error if v has a value, as counted by NArg, from any source,
+// but one of others does not, as when -tls-cert requires -tls-key.
+// The constraint applies to a FlagSet in which v and others are registered.
+// Requires returns v to permit chaining.
+func (v *Value) Requires(others ...*Value) *Value {
+	rules = append(rules, func(fs *flag.FlagSet) error {
+		if !registered(fs, v) || v.NArg() == 0 {
+			return nil
+		}
+		var errs []error
+		for _, other := range others {
+			if registered(fs, other) && other.NArg() == 0 {
+				err := fmt.Errorf(messages.Requires, strings.Join(usedNames(fs, v), ", "), other.name)
+				if v.warnOnly {
+					warnf("%v", err)
+				} else {
+					errs = append(errs, err)
+				}
+			}
+		}
+		return errors.Join(errs...)
+	})
+	return v
+}
+
+// NoDuplicates causes a value that repeats one already given, among those from the same source,
+// such as the command line, to be rejected with an error, so that -t parse -t parse fails.
+// NoDuplicates returns v to permit chaining.
+func (v *Value) NoDuplicates() *Value {
+	v.noDups = true
+	return v
+}
+
+// WithPattern restricts the arguments of v to those that match re, which is shown in usage output.
+// WithPattern returns v to permit chaining.
+func (v *Value) WithPattern(re *regexp.Regexp) *Value {
+	v.pattern = re
+	return v.WithValidator(func(s string) error {
+		if !re.MatchString(s) {
+			return fmt.Errorf(messages.NoMatch, s, re)
+		}
+		return nil
+	})
+}
+
+// OnValidate adds fn to the constraints checked by Validate for fs. fn is called with the multiflag values
+// registered in fs, keyed by name and by alias, and may enforce any rule among them,
+// such as that -from and -to are given the same number of times. The rule is not checked for
+// other FlagSets, such as those of other subcommands, so fn may assume that its flags are present.
+func OnValidate(fs *flag.FlagSet, fn func(flags map[string]*Value) error) {
+	rules = append(rules, func(other *flag.FlagSet) error {
+		if other != fs {
+			return nil
+		}
+		flags := make(map[string]*Value)
+		visit(fs, func(v *Value) {
+			flags[v.name] = v
+			for _, alias := range v.aliases {
+				flags[alias] = v
+			}
+		})
+		return fn(flags)
+	})
+}
+
+// OnValidateWarn is OnValidate for a rule whose errors are reported as warnings, to WarningOutput.
+func OnValidateWarn(fs *flag.FlagSet, fn func(flags map[string]*Value) error) {
+	OnValidate(fs, func(flags map[string]*Value) error {
+		if err := fn(flags); err != nil {
+			warnf("%v", err)
+		}
+		return nil
+	})
+}
+
+// NonEmpty causes empty arguments, as in -trace "" or -trace=, to be rejected,
+// including empty values produced by splitting, as in -trace a,,b.
+// NonEmpty returns v to permit chaining.
+func (v *Value) NonEmpty() *Value {
+	return v.WithValidator(func(s string) error {
+		if s == "" {
+			return errors.New(messages.Empty)
+		}
+		return nil
+	})
+}
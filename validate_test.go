@@ -0,0 +1,38 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"errors"
+	"flag"
+	"testing"
+)
+
+func TestOnValidateScopedToFlagSet(t *testing.T) {
+	a := flag.NewFlagSet("a", flag.ContinueOnError)
+	StringSet(a, "from", "", "sources")
+	StringSet(a, "to", "", "destinations")
+	b := flag.NewFlagSet("b", flag.ContinueOnError)
+	StringSet(b, "name", "", "name")
+
+	errMismatch := errors.New("-from and -to differ in number")
+	OnValidate(a, func(m map[string]*Value) error {
+		if m["from"].NArg() != m["to"].NArg() {
+			return errMismatch
+		}
+		return nil
+	})
+	defer func() { rules = rules[:len(rules)-1] }()
+
+	if err := Parse(a, []string{"-from", "x"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if err := Validate(a); !errors.Is(err, errMismatch) {
+		t.Errorf("Validate(a): got %v, want %v", err, errMismatch)
+	}
+	if err := Validate(b); err != nil {
+		t.Errorf("Validate(b): got %v, want nil", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+)
+
+func newStringsVar(fn Flagger, p *[]string, name string, value string, usage string, aliases ...string) *Value {
+	v := newString(fn, name, value, usage, aliases...)
+	v.bind = func() { *p = v.Args() }
+	v.bind()
+	return v
+}
+
+// StringsVar defines a string multiflag, associated with flag, whose collected arguments are stored in p.
+// p is updated as each argument is parsed.
+func StringsVar(p *[]string, name string, value string, usage string, aliases ...string) *Value {
+	return newStringsVar(flag.Var, p, name, value, usage, aliases...)
+}
+
+// StringsVarSet defines a StringsVar multiflag, associated with the provided FlagSet.
+func StringsVarSet(flg *flag.FlagSet, p *[]string, name string, value string, usage string, aliases ...string) *Value {
+	return newStringsVar(flg.Var, p, name, value, usage, aliases...)
+}
+
+func newIntVar(fn Flagger, p *int, name string, value string, usage string, aliases ...string) *Value {
+	v := newBool(fn, name, value, usage, aliases...)
+	v.bind = func() { *p = v.NArg() }
+	v.bind()
+	return v
+}
+
+// IntVar defines a boolean multiflag, associated with flag, whose count is stored in p.
+// p is updated as each flag is parsed.
+func IntVar(p *int, name string, value string, usage string, aliases ...string) *Value {
+	return newIntVar(flag.Var, p, name, value, usage, aliases...)
+}
+
+// IntVarSet defines an IntVar multiflag, associated with the provided FlagSet.
+func IntVarSet(flg *flag.FlagSet, p *int, name string, value string, usage string, aliases ...string) *Value {
+	return newIntVar(flg.Var, p, name, value, usage, aliases...)
+}
+
+// CountVar defines a boolean multiflag, associated with flag, that increments p each time it is used.
+// It is IntVar with a default value of "0".
+func CountVar(p *int, name string, usage string, aliases ...string) *Value {
+	return newIntVar(flag.Var, p, name, "0", usage, aliases...)
+}
+
+// CountVarSet defines a CountVar multiflag, associated with the provided FlagSet.
+func CountVarSet(flg *flag.FlagSet, p *int, name string, usage string, aliases ...string) *Value {
+	return newIntVar(flg.Var, p, name, "0", usage, aliases...)
+}
+
+func newStringArrayVar(fn Flagger, p *[]string, name string, value []string, usage string, aliases ...string) *Value {
+	v := newStringWithDefaults(fn, name, value, usage, aliases...).NoSplit()
+	v.bind = func() { *p = v.ArgsOrDefault() }
+	v.bind()
+	return v
+}
+
+// StringArrayVar defines a string multiflag, associated with flag, like StringArrayVar of the
+// github.com/spf13/pflag package: p holds value until the flag is used and then the arguments,
+// each as given, without splitting. It permits a plain slice to be kept, as in a configuration struct,
+// while the flag still has aliases and counts its uses.
+// p is updated as each argument is parsed.
+func StringArrayVar(p *[]string, name string, value []string, usage string, aliases ...string) *Value {
+	return newStringArrayVar(flag.Var, p, name, value, usage, aliases...)
+}
+
+// StringArrayVarSet defines a StringArrayVar multiflag, associated with the provided FlagSet.
+func StringArrayVarSet(flg *flag.FlagSet, p *[]string, name string, value []string, usage string, aliases ...string) *Value {
+	return newStringArrayVar(flg.Var, p, name, value, usage, aliases...)
+}
@@ -0,0 +1,37 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+)
+
+func newWrap(fn Flagger, inner flag.Value, name string, usage string, aliases ...string) *Value {
+	v := &Value{inner: inner, raw: true}
+	if b, ok := inner.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		v.isBool = true
+	}
+	return register(fn, v, name, usage, aliases...)
+}
+
+// Wrap returns a multiflag instance, associated with flag, that passes each argument, as given,
+// to the Set method of inner, and also collects it, so that an existing flag.Value gains
+// the counting, ordering, aliases and occurrence tracking of multiflag without being rewritten.
+// An error from inner rejects the argument. The value has no default of its own; inner keeps its own.
+// If inner is a boolean flag, the value is a Bool.
+//
+// inner is given only arguments that are in effect when they arrive, so that, under FlagsReplace,
+// an environment variable applied after the command line does not reach it. Since a flag.Value cannot
+// be cleared, inner is not told when arguments it was given are later dropped, as by a higher ranked source,
+// WithReset, Reset or Reload, and it is given the arguments applied by a Reload that then fails.
+// Where that matters, read the arguments from the returned value, which reflects all of them.
+func Wrap(inner flag.Value, name string, usage string, aliases ...string) *Value {
+	return newWrap(flag.Var, inner, name, usage, aliases...)
+}
+
+// WrapSet creates a Wrap multiflag instance, associates it with the provided FlagSet and returns it.
+func WrapSet(flg *flag.FlagSet, inner flag.Value, name string, usage string, aliases ...string) *Value {
+	return newWrap(flg.Var, inner, name, usage, aliases...)
+}
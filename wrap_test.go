@@ -0,0 +1,57 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multiflag
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+// list is a flag.Value that accumulates its arguments.
+type list []string
+
+func (l *list) String() string     { return "" }
+func (l *list) Set(s string) error { *l = append(*l, s); return nil }
+
+func TestWrapForwardsArgumentsInEffect(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		prec      Precedence
+		envFirst  bool
+		wantInner list
+		wantArgs  []string
+	}{
+		{name: "environment after command line", wantInner: list{"cli"}, wantArgs: []string{"cli"}},
+		{name: "environment appended", prec: FlagsAppend, wantInner: list{"cli", "env"}, wantArgs: []string{"env", "cli"}},
+		{name: "environment first", envFirst: true, wantInner: list{"env", "cli"}, wantArgs: []string{"cli"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var inner list
+			fs := flag.NewFlagSet("wrap", flag.ContinueOnError)
+			v := WrapSet(fs, &inner, "x", "wrapped").WithPrecedence(tc.prec)
+			vars := env(map[string]string{"X": "env"})
+			if tc.envFirst {
+				if err := bindEnv(fs, "", vars); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := Parse(fs, []string{"-x", "cli"}); err != nil {
+				t.Fatal(err)
+			}
+			if !tc.envFirst {
+				if err := bindEnv(fs, "", vars); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !reflect.DeepEqual(inner, tc.wantInner) {
+				t.Errorf("inner: got %q, want %q", inner, tc.wantInner)
+			}
+			if got := v.Args(); !reflect.DeepEqual(got, tc.wantArgs) {
+				t.Errorf("Args: got %q, want %q", got, tc.wantArgs)
+			}
+		})
+	}
+}
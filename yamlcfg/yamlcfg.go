@@ -0,0 +1,55 @@
+// Copyright 2014 Gyepi Sam. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package yamlcfg populates flags, including multiflag values, from YAML configuration files.
+
+The document must be a mapping of flag names to values. Sequences are applied as repeated
+flag arguments and scalars as single arguments, as described for multiflag.ApplyConfig.
+
+	trace: [parse, compile]
+	verbose: 2
+*/
+package yamlcfg
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gyepisam/multiflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Apply decodes the YAML document in data and applies it to fs with multiflag.ApplyConfig.
+func Apply(fs *flag.FlagSet, data []byte) error {
+	return apply(fs, "", data)
+}
+
+func apply(fs *flag.FlagSet, origin string, data []byte) error {
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	return multiflag.ApplyConfigFrom(fs, origin, values)
+}
+
+// Save writes the current values of the flags in fs, as described for multiflag.ConfigValues, to w as YAML.
+func Save(fs *flag.FlagSet, w io.Writer) error {
+	return yaml.NewEncoder(w).Encode(multiflag.ConfigValues(fs))
+}
+
+// Load reads the YAML file at path and applies it to fs.
+// Use flag.CommandLine for values created with the package level constructors.
+func Load(fs *flag.FlagSet, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := apply(fs, path, data); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}